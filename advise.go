@@ -0,0 +1,111 @@
+package goset
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Implementations that Advise can recommend.
+const (
+	AdviceMap         = "map"          // the hash map used by Set
+	AdviceBitset      = "bitset"       // one bit per possible value
+	AdviceSortedSlice = "sorted slice" // binary search over a sorted slice
+	AdviceSharded     = "sharded"      // several maps with a lock each
+)
+
+// Advice is a recommendation made by Set.Advise.
+type Advice struct {
+	// Implementation is one of the Advice* constants.
+	Implementation string
+
+	// Reason explains why Implementation is recommended.
+	Reason string
+
+	// CurrentBytes and EstimatedBytes are the estimated memory used by the
+	// set now and with the recommended implementation.
+	CurrentBytes   int64
+	EstimatedBytes int64
+}
+
+// Savings returns the estimated number of bytes saved by following the advice.
+func (a Advice) Savings() int64 {
+	return a.CurrentBytes - a.EstimatedBytes
+}
+
+const (
+	// sortedSliceMinSize is the size from which a static set is worth being
+	// turned into a sorted slice.
+	sortedSliceMinSize = 1 << 10
+
+	// shardedMinSize is the size from which a set with a lot of churn is
+	// worth being sharded to reduce lock contention.
+	shardedMinSize = 1 << 20
+)
+
+// Advise inspects the size, the churn (items removed since creation) and the
+// items of s and recommends the data structure best suited to its content:
+//
+//   - a bitset for dense, non negative integers
+//   - a sorted slice for large sets that rarely change
+//   - a sharded map for very large sets that change a lot
+//   - the current map otherwise
+//
+// The estimates are rough and are meant to guide the choice, not to be exact.
+func (s *Set) Advise() Advice {
+	st := s.Stats()
+	a := Advice{
+		Implementation: AdviceMap,
+		Reason:         "the set is small or its content doesn't fit a more compact representation",
+		CurrentBytes:   st.EstimatedBytes,
+		EstimatedBytes: st.EstimatedBytes,
+	}
+
+	s.rlock()
+	removed := s.removedCount
+	max, dense := s.maxInteger()
+	s.l.RUnlock()
+
+	switch {
+	case st.Size == 0:
+		a.Reason = "the set is empty"
+	case dense && max/8+1 < a.CurrentBytes/2:
+		a.Implementation = AdviceBitset
+		a.EstimatedBytes = max/8 + 1
+		a.Reason = fmt.Sprintf("all %d items are integers between 0 and %d", st.Size, max)
+	case st.Size >= shardedMinSize && removed >= st.Size:
+		a.Implementation = AdviceSharded
+		a.Reason = fmt.Sprintf("%d items were removed from a set of %d, spreading writes over several locks reduces contention", removed, st.Size)
+	case st.Size >= sortedSliceMinSize && removed*10 < st.Size:
+		a.Implementation = AdviceSortedSlice
+		a.EstimatedBytes = st.EstimatedBytes - int64(st.Size)*(mapEntryBytes-16) - mapHeaderBytes
+		a.Reason = fmt.Sprintf("the set holds %d items and barely changes (%d removed), a sorted slice avoids the map overhead", st.Size, removed)
+	}
+	return a
+}
+
+// maxInteger returns the largest item of an integer set and whether all items
+// are non negative. The read lock must be held.
+func (s *Set) maxInteger() (max int64, ok bool) {
+	for item := range s.m {
+		v := reflect.ValueOf(item)
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if v.Int() < 0 {
+				return 0, false
+			}
+			if v.Int() > max {
+				max = v.Int()
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if v.Uint() > 1<<62 {
+				return 0, false
+			}
+			if int64(v.Uint()) > max {
+				max = int64(v.Uint())
+			}
+		default:
+			return 0, false
+		}
+	}
+	return max, true
+}
@@ -0,0 +1,52 @@
+package goset
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestSet_Advise(t *testing.T) {
+	dense := New(reflect.Int)
+	for i := 0; i < 1000; i++ {
+		dense.Add(i)
+	}
+
+	static := New(reflect.String)
+	for i := 0; i < 2000; i++ {
+		static.Add(fmt.Sprintf("item%d", i))
+	}
+
+	churned := static.Copy()
+	for i := 0; i < 500; i++ {
+		churned.Remove(fmt.Sprintf("item%d", i))
+		churned.Add(fmt.Sprintf("other%d", i))
+	}
+
+	sparse := New(reflect.Int, 1, 1<<40)
+
+	tests := []struct {
+		name string
+		s    *Set
+		impl string
+	}{
+		{"empty", New(reflect.Int), AdviceMap},
+		{"dense", dense, AdviceBitset},
+		{"sparse", sparse, AdviceMap},
+		{"static", static, AdviceSortedSlice},
+		{"churned", churned, AdviceMap},
+	}
+
+	for _, test := range tests {
+		a := test.s.Advise()
+		if a.Implementation != test.impl {
+			t.Errorf("Advise: %s set should be advised %q, got %q (%s)", test.name, test.impl, a.Implementation, a.Reason)
+		}
+		if a.Savings() < 0 {
+			t.Errorf("Advise: %s set advice should not cost memory", test.name)
+		}
+		if test.impl != AdviceMap && a.Savings() == 0 {
+			t.Errorf("Advise: %s set advice should estimate savings", test.name)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package goset
+
+import "reflect"
+
+// NewWithCapacity creates a new, empty Set of the given kind pre-sized to hold
+// n items without growing, see WithCapacity.
+func NewWithCapacity(kind reflect.Kind, n int) *Set {
+	return NewWithOptions(kind, WithCapacity(n))
+}
+
+// Grow pre-sizes s to hold n more items without growing, to avoid repeated
+// rehashing before a bulk load. Go maps can't be grown in place, so the
+// items are copied to a new map once.
+func (s *Set) Grow(n int) {
+	if n <= 0 {
+		return
+	}
+
+	s.lock()
+	defer s.unlock()
+
+	s.rebuild(len(s.m) + n)
+}
+
+// rebuild copies the items of s to a new map sized for capacity items. The
+// write lock must be held.
+func (s *Set) rebuild(capacity int) {
+	m := make(map[interface{}]struct{}, capacity)
+	for item := range s.m {
+		m[item] = struct{}{}
+	}
+	s.m = m
+	s.shared = false
+}
+
+// Compact releases the memory the backing map of s kept from its peak size,
+// by copying the items to a new map sized for the current items. Go maps
+// never shrink, so long-lived sets should be compacted after mass removals.
+func (s *Set) Compact() {
+	s.lock()
+	defer s.unlock()
+
+	s.rebuild(len(s.m))
+}
@@ -0,0 +1,81 @@
+package goset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewWithCapacity(t *testing.T) {
+	s := NewWithCapacity(reflect.Int, 100)
+	if !s.IsEmpty() || s.Kind() != reflect.Int {
+		t.Errorf("NewWithCapacity: expected an empty int set, got %s", s)
+	}
+}
+
+func TestSet_Grow(t *testing.T) {
+	s := New(reflect.Int, 1, 2)
+	f := s.Snapshot()
+
+	s.Grow(1000)
+	s.Add(3)
+	if ok, _ := s.Has(1, 2, 3); !ok || s.Size() != 3 {
+		t.Errorf("Grow: the items should be kept, got %s", s)
+	}
+	if f.Size() != 2 {
+		t.Error("Grow: snapshots should not be modified")
+	}
+
+	grown := testing.AllocsPerRun(1, func() {
+		u := New(reflect.Int)
+		u.Grow(1000)
+		for i := 0; i < 1000; i++ {
+			u.Add(i)
+		}
+	})
+	plain := testing.AllocsPerRun(1, func() {
+		u := New(reflect.Int)
+		for i := 0; i < 1000; i++ {
+			u.Add(i)
+		}
+	})
+	if grown >= plain {
+		t.Errorf("Grow: expected fewer allocations, got %v >= %v", grown, plain)
+	}
+}
+
+func TestSet_Compact(t *testing.T) {
+	s := New(reflect.Int)
+	for i := 0; i < 10000; i++ {
+		s.Add(i)
+	}
+	for i := 10; i < 10000; i++ {
+		s.Remove(i)
+	}
+	f := s.Snapshot()
+
+	s.Compact()
+	if s.Size() != 10 || f.Size() != 10 {
+		t.Errorf("Compact: the items should be kept, got %d", s.Size())
+	}
+	s.Add(10)
+	if f.Size() != 10 {
+		t.Error("Compact: snapshots should not be modified")
+	}
+}
+
+func TestWithRetainCapacity(t *testing.T) {
+	s := NewWithOptions(reflect.Int, WithRetainCapacity(), WithItems(1, 2, 3))
+	f := s.Snapshot()
+
+	s.Clear()
+	if !s.IsEmpty() || f.Size() != 3 {
+		t.Error("Clear: a shared map should not be cleared in place")
+	}
+
+	s.Add(1, 2, 3)
+	s.Clear()
+	s.Add(4)
+	if ok, _ := s.Has(4); !ok || s.Size() != 1 {
+		t.Errorf("Clear: expected [4], got %s", s)
+	}
+}
@@ -0,0 +1,101 @@
+package goset
+
+// Chain wraps a Set to apply a sequence of in-place operations fluently:
+//
+//	err := s.Chain().Merge(a).Separate(b).Intersect(c).Err()
+//
+// Every method modifies the underlying set and returns the receiver. The first
+// error encountered is recorded and all subsequent operations are skipped.
+type Chain struct {
+	s   *Set
+	err error
+}
+
+// Chain returns a fluent wrapper around s.
+func (s *Set) Chain() *Chain {
+	return &Chain{s: s}
+}
+
+// With adds items to s and returns a Chain to continue with, see Chain.With.
+func (s *Set) With(items ...interface{}) *Chain {
+	return s.Chain().With(items...)
+}
+
+// Without removes items from s and returns a Chain to continue with, see
+// Chain.Without.
+func (s *Set) Without(items ...interface{}) *Chain {
+	return s.Chain().Without(items...)
+}
+
+// Set returns the underlying set.
+func (c *Chain) Set() *Set {
+	return c.s
+}
+
+// Err returns the first error encountered in the chain, if any.
+func (c *Chain) Err() error {
+	return c.err
+}
+
+// With is like Set.Add.
+func (c *Chain) With(items ...interface{}) *Chain {
+	if c.err == nil {
+		c.err = c.s.Add(items...)
+	}
+	return c
+}
+
+// Without is like Set.Remove.
+func (c *Chain) Without(items ...interface{}) *Chain {
+	if c.err == nil {
+		c.err = c.s.Remove(items...)
+	}
+	return c
+}
+
+// UnionWith is an alias of Merge.
+func (c *Chain) UnionWith(t Interface) *Chain {
+	return c.Merge(t)
+}
+
+// Merge is like Set.Merge.
+func (c *Chain) Merge(t Interface) *Chain {
+	if c.err == nil {
+		c.err = c.s.Merge(t)
+	}
+	return c
+}
+
+// Separate is like Set.Separate.
+func (c *Chain) Separate(t Interface) *Chain {
+	if c.err == nil {
+		c.err = c.s.Separate(t)
+	}
+	return c
+}
+
+// Intersect is like Set.RetainAll.
+func (c *Chain) Intersect(t Interface) *Chain {
+	if c.err == nil {
+		c.err = c.s.RetainAll(t)
+	}
+	return c
+}
+
+// Retain removes every item for which pred returns false.
+func (c *Chain) Retain(pred func(item interface{}) bool) *Chain {
+	if c.err != nil {
+		return c
+	}
+
+	c.s.lock()
+	defer c.s.unlock()
+
+	for item := range c.s.m {
+		if !pred(item) {
+			c.s.delete(item)
+		}
+	}
+	c.err = c.s.flushWAL()
+	return c
+}
@@ -0,0 +1,58 @@
+package goset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChain(t *testing.T) {
+	s := New(reflect.Int, 1, 2)
+	err := s.Chain().
+		Merge(New(reflect.Int, 3, 4, 5, 6)).
+		Separate(New(reflect.Int, 1)).
+		Intersect(New(reflect.Int, 2, 3, 4, 5)).
+		Retain(func(item interface{}) bool { return item.(int) != 5 }).
+		Err()
+
+	if err != nil {
+		t.Fatalf("Chain: unexpected error: %s", err)
+	}
+
+	if ok, _ := s.Has(2, 3, 4); !ok || s.Size() != 3 {
+		t.Errorf("Chain: set should be [2, 3, 4], got %s", s)
+	}
+}
+
+func TestChain_error(t *testing.T) {
+	s := New(reflect.Int, 1, 2)
+	c := s.Chain().
+		Merge(New(reflect.String, "a")).
+		Merge(New(reflect.Int, 3))
+
+	if c.Err() == nil {
+		t.Error("Chain: merging mismatched sets should record an error")
+	}
+
+	if c.Set() != s || s.Size() != 2 {
+		t.Error("Chain: operations after an error should be skipped")
+	}
+}
+
+func TestSet_With(t *testing.T) {
+	s := New(reflect.String, "a")
+	err := s.With("b", "c").Without("a").UnionWith(New(reflect.String, "d")).Err()
+	if err != nil {
+		t.Fatalf("With: unexpected error: %s", err)
+	}
+	if ok, _ := s.Has("b", "c", "d"); !ok || s.Size() != 3 {
+		t.Errorf("With: set should be [b, c, d], got %s", s)
+	}
+
+	c := s.Without(1).With("e")
+	if c.Err() == nil {
+		t.Error("Without: items of another kind should record an error")
+	}
+	if ok, _ := s.Has("e"); ok {
+		t.Error("With: operations after an error should be skipped")
+	}
+}
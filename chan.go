@@ -0,0 +1,88 @@
+package goset
+
+import "context"
+
+// Chan returns a channel that receives every item of s and is closed
+// afterwards, or as soon as ctx is done. The items are the ones of s when Chan
+// is called, later changes are not reflected. The consumer must either
+// receive until the channel is closed or cancel ctx, otherwise the goroutine
+// feeding the channel leaks.
+func (s *Set) Chan(ctx context.Context) <-chan interface{} {
+	items := s.List()
+	ch := make(chan interface{})
+
+	go func() {
+		defer close(ch)
+		for _, item := range items {
+			select {
+			case ch <- item:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// addFromBatch is the maximum number of items AddFrom adds at once.
+const addFromBatch = 256
+
+// AddFrom adds the items received from ch to s until ch is closed, in which
+// case it returns nil, or until ctx is done, in which case it returns the
+// error of ctx. Items that are ready to be received are added in batches, so
+// that the lock of s is taken once per batch rather than once per item. If an
+// item doesn't match the kind of s, AddFrom stops and returns an error; the
+// items received before it are added.
+func (s *Set) AddFrom(ctx context.Context, ch <-chan interface{}) error {
+	batch := make([]interface{}, 0, addFromBatch)
+	for {
+		select {
+		case item, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			batch = append(batch[:0], item)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		closed := false
+	receive:
+		for len(batch) < addFromBatch {
+			select {
+			case item, ok := <-ch:
+				if !ok {
+					closed = true
+					break receive
+				}
+				batch = append(batch, item)
+			default:
+				break receive
+			}
+		}
+
+		if err := s.addBatch(batch); err != nil {
+			return err
+		}
+		if closed {
+			return nil
+		}
+	}
+}
+
+// addBatch adds the items of batch up to the first one that doesn't match the
+// kind of s, whose error it returns.
+func (s *Set) addBatch(batch []interface{}) error {
+	schedule("Add")
+	s.lock()
+	defer s.unlock()
+
+	for _, item := range batch {
+		if err := s.typecheck(item); err != nil {
+			return err
+		}
+		s.insert(item)
+	}
+	return s.flushWAL()
+}
@@ -0,0 +1,63 @@
+package goset_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/fatih/goset"
+	"github.com/fatih/goset/settest"
+)
+
+func TestSet_Chan(t *testing.T) {
+	s := goset.New(reflect.Int, 1, 2, 3)
+	settest.VerifyNoLeaks(t, s)
+
+	u := goset.New(reflect.Int)
+	for item := range s.Chan(context.Background()) {
+		u.Add(item)
+	}
+	if ok, _ := s.IsEqual(u); !ok {
+		t.Errorf("Chan: expected all items, got %s", u)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := s.Chan(ctx)
+	<-ch
+	cancel()
+	for range ch {
+	}
+}
+
+func TestSet_AddFrom(t *testing.T) {
+	s := goset.New(reflect.Int)
+
+	ch := make(chan interface{}, 1000)
+	for i := 0; i < 1000; i++ {
+		ch <- i % 500
+	}
+	close(ch)
+	if err := s.AddFrom(context.Background(), ch); err != nil {
+		t.Fatal(err)
+	}
+	if s.Size() != 500 {
+		t.Errorf("AddFrom: expected 500 items, got %d", s.Size())
+	}
+
+	ch = make(chan interface{}, 3)
+	ch <- 1000
+	ch <- "a"
+	ch <- 1001
+	if err := s.AddFrom(context.Background(), ch); err == nil {
+		t.Error("AddFrom: items of another kind should return an error")
+	}
+	if ok, _ := s.Has(1000); !ok {
+		t.Error("AddFrom: items received before an error should be added")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := s.AddFrom(ctx, make(chan interface{})); err != context.Canceled {
+		t.Errorf("AddFrom: expected the error of the context, got %v", err)
+	}
+}
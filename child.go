@@ -0,0 +1,145 @@
+package goset
+
+import (
+	"reflect"
+	"sync"
+)
+
+// view is the read access a ChildSet needs to its parent.
+type view interface {
+	Kind() reflect.Kind
+	Has(items ...interface{}) (bool, error)
+	List() []interface{}
+}
+
+// ChildSet is an overlay on top of a parent set: it sees the items of its
+// parent, plus the items added to it, minus the items removed from it. The
+// parent is never modified, and changes of the parent are visible through the
+// child. A ChildSet is safe for concurrent use.
+type ChildSet struct {
+	parent  view
+	kind    reflect.Kind
+	l       sync.RWMutex
+	added   map[interface{}]struct{}
+	removed map[interface{}]struct{}
+}
+
+// Child returns a new overlay on top of s, see ChildSet.
+func (s *Set) Child() *ChildSet {
+	return newChild(s)
+}
+
+// Child returns a new overlay on top of c.
+func (c *ChildSet) Child() *ChildSet {
+	return newChild(c)
+}
+
+func newChild(parent view) *ChildSet {
+	return &ChildSet{
+		parent:  parent,
+		kind:    parent.Kind(),
+		added:   make(map[interface{}]struct{}),
+		removed: make(map[interface{}]struct{}),
+	}
+}
+
+// Kind returns the kind of the items the set holds.
+func (c *ChildSet) Kind() reflect.Kind {
+	return c.kind
+}
+
+// Add includes the specified items in the child, without modifying the
+// parent.
+func (c *ChildSet) Add(items ...interface{}) error {
+	if err := typecheck(c.kind, items...); err != nil {
+		return err
+	}
+
+	c.l.Lock()
+	defer c.l.Unlock()
+
+	for _, item := range items {
+		item = normalize(item)
+		delete(c.removed, item)
+		c.added[item] = struct{}{}
+	}
+	return nil
+}
+
+// Remove hides the specified items from the child, without modifying the
+// parent. They stay hidden even if they're added to the parent later.
+func (c *ChildSet) Remove(items ...interface{}) error {
+	if err := typecheck(c.kind, items...); err != nil {
+		return err
+	}
+
+	c.l.Lock()
+	defer c.l.Unlock()
+
+	for _, item := range items {
+		item = normalize(item)
+		delete(c.added, item)
+		c.removed[item] = struct{}{}
+	}
+	return nil
+}
+
+// Has looks for the existence of items passed in the child. It returns false
+// if nothing is passed. For multiple items it returns true only if all of the
+// items exist.
+func (c *ChildSet) Has(items ...interface{}) (bool, error) {
+	if len(items) == 0 {
+		return false, nil
+	}
+	if err := typecheck(c.kind, items...); err != nil {
+		return false, err
+	}
+
+	c.l.RLock()
+	defer c.l.RUnlock()
+
+	for _, item := range items {
+		item = normalize(item)
+		if _, ok := c.removed[item]; ok {
+			return false, nil
+		}
+		if _, ok := c.added[item]; ok {
+			continue
+		}
+		if ok, _ := c.parent.Has(item); !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// List returns a slice of all items of the child.
+func (c *ChildSet) List() []interface{} {
+	parent := c.parent.List()
+
+	c.l.RLock()
+	defer c.l.RUnlock()
+
+	list := make([]interface{}, 0, len(parent)+len(c.added))
+	for _, item := range parent {
+		_, removed := c.removed[item]
+		_, added := c.added[item]
+		if !removed && !added {
+			list = append(list, item)
+		}
+	}
+	for item := range c.added {
+		list = append(list, item)
+	}
+	return list
+}
+
+// Size returns the number of items of the child.
+func (c *ChildSet) Size() int {
+	return len(c.List())
+}
+
+// Flatten returns a new, independent Set with the items of the child.
+func (c *ChildSet) Flatten() *Set {
+	return New(c.kind, c.List()...)
+}
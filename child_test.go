@@ -0,0 +1,60 @@
+package goset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSet_Child(t *testing.T) {
+	base := New(reflect.String, "a", "b", "c")
+	c := base.Child()
+
+	c.Add("d", "a")
+	c.Remove("b")
+
+	if ok, _ := c.Has("a", "c", "d"); !ok {
+		t.Error("Child: parent and added items should be visible")
+	}
+	if ok, _ := c.Has("b"); ok {
+		t.Error("Child: removed items should be hidden")
+	}
+	if c.Size() != 3 {
+		t.Errorf("Child: expected three items, got %v", c.List())
+	}
+
+	if base.Size() != 3 {
+		t.Error("Child: the parent should not be modified")
+	}
+
+	base.Add("e", "b")
+	if ok, _ := c.Has("e"); !ok || c.Size() != 4 {
+		t.Error("Child: later additions to the parent should be visible")
+	}
+	if ok, _ := c.Has("b"); ok {
+		t.Error("Child: removed items should stay hidden when added to the parent")
+	}
+
+	flat := c.Flatten()
+	if ok, _ := flat.Has("a", "c", "d", "e"); !ok || flat.Size() != 4 {
+		t.Errorf("Flatten: unexpected items %s", flat)
+	}
+
+	if err := c.Add(1); err == nil {
+		t.Error("Child: items of another kind should return an error")
+	}
+}
+
+func TestChildSet_Child(t *testing.T) {
+	base := New(reflect.Int, 1, 2)
+	c := base.Child()
+	c.Add(3)
+	gc := c.Child()
+	gc.Remove(1)
+
+	if ok, _ := gc.Has(2, 3); !ok || gc.Size() != 2 {
+		t.Errorf("Child: grandchild should see [2 3], got %v", gc.List())
+	}
+	if c.Size() != 3 {
+		t.Error("Child: the child should not be modified by the grandchild")
+	}
+}
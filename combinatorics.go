@@ -0,0 +1,119 @@
+package goset
+
+import "reflect"
+
+// Pair is an ordered pair of items, as produced by Product. Pairs are
+// comparable, so they can be stored in a set of kind reflect.Struct.
+type Pair struct {
+	First  interface{}
+	Second interface{}
+}
+
+// Product returns the cartesian product of s and t: a new set of kind
+// reflect.Struct holding a Pair for every combination of an item of s (First)
+// with an item of t (Second). The sets don't need to be of the same kind.
+func (s *Set) Product(t Interface) *Set {
+	left, right := s.List(), t.List()
+
+	p := NewWithOptions(reflect.Struct, WithCapacity(len(left)*len(right)))
+	for _, a := range left {
+		for _, b := range right {
+			p.m[Pair{First: a, Second: b}] = struct{}{}
+		}
+	}
+	return p
+}
+
+// PowerSet returns all subsets of s, including the empty set and a copy of s
+// itself. A set with n items has 2^n subsets, use EachSubset to avoid holding
+// all of them in memory at once.
+func (s *Set) PowerSet() []*Set {
+	subsets := make([]*Set, 0)
+	s.EachSubset(func(subset *Set) bool {
+		subsets = append(subsets, subset)
+		return true
+	})
+	return subsets
+}
+
+// EachSubset calls fn for every subset of s, starting with the empty set. Each
+// subset is a new set owned by fn. Iteration stops early if fn returns false.
+// The subsets are generated from a snapshot of s taken before the first call.
+func (s *Set) EachSubset(fn func(subset *Set) bool) {
+	items := s.List()
+
+	// in is a binary counter, in[i] reports whether items[i] is part of the
+	// current subset.
+	in := make([]bool, len(items))
+	for {
+		subset := New(s.kind)
+		for i, item := range items {
+			if in[i] {
+				subset.m[item] = struct{}{}
+			}
+		}
+		if !fn(subset) {
+			return
+		}
+
+		i := 0
+		for ; i < len(in) && in[i]; i++ {
+			in[i] = false
+		}
+		if i == len(in) {
+			return
+		}
+		in[i] = true
+	}
+}
+
+// Combinations returns all subsets of s with exactly k items. It returns no
+// sets if k is negative or larger than the size of s. Use EachCombination to
+// avoid holding all of them in memory at once.
+func (s *Set) Combinations(k int) []*Set {
+	subsets := make([]*Set, 0)
+	s.EachCombination(k, func(subset *Set) bool {
+		subsets = append(subsets, subset)
+		return true
+	})
+	return subsets
+}
+
+// EachCombination calls fn for every subset of s with exactly k items. Each
+// subset is a new set owned by fn. Iteration stops early if fn returns false.
+// The subsets are generated from a snapshot of s taken before the first call.
+func (s *Set) EachCombination(k int, fn func(subset *Set) bool) {
+	items := s.List()
+	n := len(items)
+	if k < 0 || k > n {
+		return
+	}
+
+	// idx holds the indexes of the chosen items in ascending order
+	idx := make([]int, k)
+	for i := range idx {
+		idx[i] = i
+	}
+
+	for {
+		subset := NewWithOptions(s.kind, WithCapacity(k))
+		for _, i := range idx {
+			subset.m[items[i]] = struct{}{}
+		}
+		if !fn(subset) {
+			return
+		}
+
+		// advance the rightmost index that hasn't reached its maximum
+		i := k - 1
+		for ; i >= 0 && idx[i] == n-k+i; i-- {
+		}
+		if i < 0 {
+			return
+		}
+		idx[i]++
+		for j := i + 1; j < k; j++ {
+			idx[j] = idx[j-1] + 1
+		}
+	}
+}
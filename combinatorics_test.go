@@ -0,0 +1,106 @@
+package goset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSet_PowerSet(t *testing.T) {
+	s := New(reflect.Int, 1, 2, 3)
+	subsets := s.PowerSet()
+
+	if len(subsets) != 8 {
+		t.Fatal("PowerSet: a set of three items should have eight subsets")
+	}
+
+	// encode every subset as a bit mask of its items to check uniqueness
+	seen := New(reflect.Int)
+	for _, u := range subsets {
+		if ok, _ := s.IsSubset(u); !ok {
+			t.Errorf("PowerSet: %s is not a subset of %s", u, s)
+		}
+		mask := 0
+		for _, item := range u.IntSlice() {
+			mask |= 1 << uint(item)
+		}
+		seen.Add(mask)
+	}
+
+	if seen.Size() != 8 {
+		t.Error("PowerSet: subsets should be unique")
+	}
+
+	if len(New(reflect.Int).PowerSet()) != 1 {
+		t.Error("PowerSet: the empty set should only have the empty set as subset")
+	}
+}
+
+func TestSet_EachSubset_stop(t *testing.T) {
+	s := New(reflect.Int, 1, 2, 3, 4)
+
+	calls := 0
+	s.EachSubset(func(subset *Set) bool {
+		calls++
+		return calls < 3
+	})
+
+	if calls != 3 {
+		t.Error("EachSubset: iteration should stop when fn returns false")
+	}
+}
+
+func TestSet_Product(t *testing.T) {
+	s := New(reflect.String, "a", "b")
+	u := New(reflect.Int, 1, 2, 3)
+	p := s.Product(u)
+
+	if p.Kind() != reflect.Struct || p.Size() != 6 {
+		t.Fatal("Product: product of two and three items should hold six pairs")
+	}
+
+	if ok, _ := p.Has(Pair{"a", 1}, Pair{"a", 3}, Pair{"b", 2}); !ok {
+		t.Error("Product: expected pairs are not available in the set")
+	}
+
+	if ok, _ := p.Has(Pair{1, "a"}); ok {
+		t.Error("Product: pairs should be ordered")
+	}
+
+	if s.Product(New(reflect.Int)).Size() != 0 {
+		t.Error("Product: product with the empty set should be empty")
+	}
+
+	if p := s.Product(NewCounting(reflect.Int, 1, 1)); p.Size() != 2 {
+		t.Errorf("Product: other implementations of Interface should be accepted, got %s", p)
+	}
+}
+
+func TestSet_Combinations(t *testing.T) {
+	s := New(reflect.Int, 1, 2, 3, 4, 5)
+
+	tests := []struct{ k, n int }{{-1, 0}, {0, 1}, {1, 5}, {2, 10}, {3, 10}, {5, 1}, {6, 0}}
+	for _, test := range tests {
+		subsets := s.Combinations(test.k)
+		if len(subsets) != test.n {
+			t.Errorf("Combinations: expected %d subsets of size %d, got %d", test.n, test.k, len(subsets))
+		}
+
+		seen := New(reflect.Int)
+		for _, u := range subsets {
+			if u.Size() != test.k {
+				t.Errorf("Combinations: subset %s should have %d items", u, test.k)
+			}
+			if ok, _ := s.IsSubset(u); !ok {
+				t.Errorf("Combinations: %s is not a subset of %s", u, s)
+			}
+			mask := 0
+			for _, item := range u.IntSlice() {
+				mask |= 1 << uint(item)
+			}
+			seen.Add(mask)
+		}
+		if seen.Size() != len(subsets) {
+			t.Errorf("Combinations: subsets of size %d should be unique", test.k)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+// Package configdiff reports the differences between two configurations,
+// given as maps or structs, built on the set operations of goset.
+package configdiff
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/fatih/goset"
+)
+
+// Change is a single difference between two configurations.
+type Change struct {
+	Key string      `json:"key"`
+	Old interface{} `json:"old,omitempty"`
+	New interface{} `json:"new,omitempty"`
+}
+
+// Report lists the keys added, removed and changed between two
+// configurations, each sorted by key.
+type Report struct {
+	Added   []Change `json:"added"`
+	Removed []Change `json:"removed"`
+	Changed []Change `json:"changed"`
+}
+
+// IsEmpty reports whether the configurations are the same.
+func (r *Report) IsEmpty() bool {
+	return len(r.Added) == 0 && len(r.Removed) == 0 && len(r.Changed) == 0
+}
+
+// Diff compares two configurations. Both must be maps or structs, or pointers
+// to them. Nested maps and structs are flattened into dotted keys
+// ("server.port"), the keys of a map are formatted with fmt.Sprint and struct
+// fields are keyed by name. Unexported fields are ignored. Values are compared
+// with reflect.DeepEqual.
+func Diff(old, new interface{}) (*Report, error) {
+	before, err := flatten(old)
+	if err != nil {
+		return nil, err
+	}
+	after, err := flatten(new)
+	if err != nil {
+		return nil, err
+	}
+
+	oldKeys, newKeys := keys(before), keys(after)
+	added, _ := newKeys.Difference(oldKeys)
+	removed, _ := oldKeys.Difference(newKeys)
+	common, _ := oldKeys.Intersection(newKeys)
+
+	r := &Report{
+		Added:   make([]Change, 0),
+		Removed: make([]Change, 0),
+		Changed: make([]Change, 0),
+	}
+	for _, k := range sorted(added) {
+		r.Added = append(r.Added, Change{Key: k, New: after[k]})
+	}
+	for _, k := range sorted(removed) {
+		r.Removed = append(r.Removed, Change{Key: k, Old: before[k]})
+	}
+	for _, k := range sorted(common) {
+		if !reflect.DeepEqual(before[k], after[k]) {
+			r.Changed = append(r.Changed, Change{Key: k, Old: before[k], New: after[k]})
+		}
+	}
+	return r, nil
+}
+
+func keys(m map[string]interface{}) *goset.Set {
+	s := goset.NewWithOptions(reflect.String, goset.WithCapacity(len(m)))
+	for k := range m {
+		s.Add(k)
+	}
+	return s
+}
+
+func sorted(s *goset.Set) []string {
+	list := s.StringSlice()
+	sort.Strings(list)
+	return list
+}
+
+// flatten returns the leaf values of a map or struct keyed by their dotted
+// path.
+func flatten(config interface{}) (map[string]interface{}, error) {
+	v := reflect.Indirect(reflect.ValueOf(config))
+	if v.Kind() != reflect.Map && v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("configdiff: expected a map or a struct, got '%T'", config)
+	}
+
+	leaves := make(map[string]interface{})
+	walk("", v, leaves)
+	return leaves, nil
+}
+
+func walk(prefix string, v reflect.Value, leaves map[string]interface{}) {
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			break
+		}
+		v = v.Elem()
+	}
+
+	join := func(key string) string {
+		if prefix == "" {
+			return key
+		}
+		return prefix + "." + key
+	}
+
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Len() > 0 || prefix == "" {
+			for _, k := range v.MapKeys() {
+				walk(join(fmt.Sprint(k.Interface())), v.MapIndex(k), leaves)
+			}
+			return
+		}
+	case reflect.Struct:
+		if v.NumField() > 0 {
+			for i := 0; i < v.NumField(); i++ {
+				if f := v.Type().Field(i); f.IsExported() {
+					walk(join(f.Name), v.Field(i), leaves)
+				}
+			}
+			return
+		}
+	}
+
+	if v.IsValid() && v.CanInterface() {
+		leaves[prefix] = v.Interface()
+	} else {
+		leaves[prefix] = nil
+	}
+}
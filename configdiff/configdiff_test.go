@@ -0,0 +1,80 @@
+package configdiff
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type server struct {
+	Host    string
+	Port    int
+	TLS     *bool
+	secret  string
+	Options map[string]interface{}
+}
+
+type config struct {
+	Name   string
+	Server server
+}
+
+func TestDiff_struct(t *testing.T) {
+	yes := true
+	old := config{Name: "app", Server: server{Host: "a", Port: 80, secret: "x", Options: map[string]interface{}{"legacy": 1, "gzip": true}}}
+	new := &config{Name: "app", Server: server{Host: "a", Port: 8080, TLS: &yes, Options: map[string]interface{}{"gzip": true, "http2": true}}}
+
+	r, err := Diff(old, new)
+	if err != nil {
+		t.Fatal("Diff: unexpected error", err)
+	}
+
+	var out strings.Builder
+	Text.Format(&out, r)
+
+	expected := `+ Server.Options.http2 = true
+- Server.Options.legacy = 1
+~ Server.Port: 80 -> 8080
+~ Server.TLS: <nil> -> true
+`
+	if out.String() != expected {
+		t.Errorf("Diff: expected\n%s\ngot\n%s", expected, out.String())
+	}
+}
+
+func TestDiff_map(t *testing.T) {
+	old := map[string]int{"a": 1, "b": 2}
+	new := map[string]int{"a": 1, "b": 2}
+
+	r, err := Diff(old, new)
+	if err != nil || !r.IsEmpty() {
+		t.Errorf("Diff: equal maps should produce an empty report, got %+v (%v)", r, err)
+	}
+
+	new["c"] = 3
+	r, _ = Diff(old, new)
+
+	var out strings.Builder
+	if err := JSON.Format(&out, r); err != nil {
+		t.Fatal("JSON: unexpected error", err)
+	}
+
+	var decoded struct {
+		Added   []Change
+		Removed []Change
+		Changed []Change
+	}
+	if err := json.Unmarshal([]byte(out.String()), &decoded); err != nil {
+		t.Fatal("JSON: invalid output", err)
+	}
+	if len(decoded.Added) != 1 || decoded.Added[0].Key != "c" || decoded.Added[0].New != 3.0 {
+		t.Errorf("JSON: unexpected output %s", out.String())
+	}
+	if decoded.Removed == nil || decoded.Changed == nil {
+		t.Error("JSON: empty lists should be encoded as []")
+	}
+
+	if _, err := Diff(1, new); err == nil {
+		t.Error("Diff: values that aren't maps or structs should return an error")
+	}
+}
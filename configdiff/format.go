@@ -0,0 +1,50 @@
+package configdiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Formatter writes a Report in a given format.
+type Formatter interface {
+	Format(w io.Writer, r *Report) error
+}
+
+// FormatterFunc adapts a function to the Formatter interface.
+type FormatterFunc func(w io.Writer, r *Report) error
+
+// Format calls f(w, r).
+func (f FormatterFunc) Format(w io.Writer, r *Report) error {
+	return f(w, r)
+}
+
+// Text formats a report as one line per change. Added keys are printed as
+// "+ key = new", removed keys as "- key = old" and changed keys as
+// "~ key: old -> new".
+var Text Formatter = FormatterFunc(func(w io.Writer, r *Report) error {
+	for _, c := range r.Added {
+		if _, err := fmt.Fprintf(w, "+ %s = %v\n", c.Key, c.New); err != nil {
+			return err
+		}
+	}
+	for _, c := range r.Removed {
+		if _, err := fmt.Fprintf(w, "- %s = %v\n", c.Key, c.Old); err != nil {
+			return err
+		}
+	}
+	for _, c := range r.Changed {
+		if _, err := fmt.Fprintf(w, "~ %s: %v -> %v\n", c.Key, c.Old, c.New); err != nil {
+			return err
+		}
+	}
+	return nil
+})
+
+// JSON formats a report as an indented JSON object with the added, removed
+// and changed lists.
+var JSON Formatter = FormatterFunc(func(w io.Writer, r *Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+})
@@ -0,0 +1,168 @@
+package goset
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// kindTypes are the types used to convert decoded values to the basic kinds.
+var kindTypes = map[reflect.Kind]reflect.Type{
+	reflect.Bool:    reflect.TypeOf(false),
+	reflect.Int:     reflect.TypeOf(int(0)),
+	reflect.Int8:    reflect.TypeOf(int8(0)),
+	reflect.Int16:   reflect.TypeOf(int16(0)),
+	reflect.Int32:   reflect.TypeOf(int32(0)),
+	reflect.Int64:   reflect.TypeOf(int64(0)),
+	reflect.Uint:    reflect.TypeOf(uint(0)),
+	reflect.Uint8:   reflect.TypeOf(uint8(0)),
+	reflect.Uint16:  reflect.TypeOf(uint16(0)),
+	reflect.Uint32:  reflect.TypeOf(uint32(0)),
+	reflect.Uint64:  reflect.TypeOf(uint64(0)),
+	reflect.Float32: reflect.TypeOf(float32(0)),
+	reflect.Float64: reflect.TypeOf(float64(0)),
+	reflect.String:  reflect.TypeOf(""),
+}
+
+// convert converts a decoded value (a string, a bool, a float64, a
+// json.Number, or an int, int64 or uint64 as decoded from YAML) to the basic
+// type of the given kind. Strings are parsed for non string kinds.
+func convert(kind reflect.Kind, v interface{}) (interface{}, error) {
+	typ, ok := kindTypes[kind]
+	if !ok {
+		return nil, fmt.Errorf("cannot convert values to kind '%s'", kind)
+	}
+
+	var str string
+	switch x := v.(type) {
+	case string:
+		if kind == reflect.String {
+			return x, nil
+		}
+		str = x
+	case json.Number:
+		str = x.String()
+	case bool:
+		str = strconv.FormatBool(x)
+	case float64:
+		str = strconv.FormatFloat(x, 'f', -1, 64)
+	case int:
+		str = strconv.Itoa(x)
+	case int64:
+		str = strconv.FormatInt(x, 10)
+	case uint64:
+		str = strconv.FormatUint(x, 10)
+	default:
+		if v != nil && reflect.TypeOf(v) == typ {
+			return v, nil
+		}
+		return nil, fmt.Errorf("cannot convert %v of type '%T' to kind '%s'", v, v, kind)
+	}
+
+	out := reflect.New(typ).Elem()
+	switch kind {
+	case reflect.String:
+		return nil, fmt.Errorf("cannot convert %v of type '%T' to kind 'string'", v, v)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(str)
+		if err != nil {
+			return nil, err
+		}
+		out.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(str, 10, typ.Bits())
+		if err != nil {
+			return nil, err
+		}
+		out.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(str, 10, typ.Bits())
+		if err != nil {
+			return nil, err
+		}
+		out.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(str, typ.Bits())
+		if err != nil {
+			return nil, err
+		}
+		out.SetFloat(f)
+	}
+	return out.Interface(), nil
+}
+
+// inferKind returns the kind of a set holding the given decoded values:
+// string, bool, int if all numbers are integers, float64 otherwise.
+func inferKind(values []interface{}) reflect.Kind {
+	kind := reflect.Invalid
+	for _, v := range values {
+		k := reflect.Invalid
+		switch x := v.(type) {
+		case string:
+			k = reflect.String
+		case bool:
+			k = reflect.Bool
+		case json.Number:
+			k = reflect.Int
+			if _, err := x.Int64(); err != nil {
+				k = reflect.Float64
+			}
+		case float64:
+			k = reflect.Float64
+		case int, int64:
+			k = reflect.Int
+		case uint64:
+			k = reflect.Uint64
+		}
+
+		switch {
+		case kind == reflect.Invalid:
+			kind = k
+		case kind == reflect.Int && k == reflect.Float64:
+			kind = reflect.Float64
+		case kind == reflect.Float64 && k == reflect.Int:
+		case kind != k:
+			return reflect.Invalid
+		}
+	}
+	return kind
+}
+
+// replaceWith replaces the items of s with values decoded from a textual
+// representation, converted to the kind of s. If s has no kind, as a zero
+// Set, the kind is inferred from the values first.
+func (s *Set) replaceWith(values []interface{}) error {
+	kind := s.Kind()
+	if kind == reflect.Invalid {
+		if kind = inferKind(values); kind == reflect.Invalid && len(values) > 0 {
+			return fmt.Errorf("cannot infer the kind of a set from %v", values)
+		}
+	}
+
+	items := make([]interface{}, len(values))
+	for i, v := range values {
+		item, err := convert(kind, v)
+		if err != nil {
+			return err
+		}
+		items[i] = item
+	}
+
+	s.lock()
+	defer s.unlock()
+
+	if s.m == nil {
+		s.m = make(map[interface{}]struct{}, len(items))
+	}
+	if s.kind == reflect.Invalid {
+		s.kind = kind
+	}
+	for item := range s.m {
+		s.delete(item)
+	}
+	for _, item := range items {
+		s.insert(item)
+	}
+	return s.flushWAL()
+}
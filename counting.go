@@ -0,0 +1,144 @@
+package goset
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// CountedItem is an item of a CountingSet with the number of times it was
+// added.
+type CountedItem struct {
+	Item  interface{}
+	Count int64
+}
+
+// CountingSet is a set that records how many times each item was added.
+// Membership follows set semantics: Remove deletes an item whatever its
+// count. It's safe for concurrent use.
+type CountingSet struct {
+	kind reflect.Kind
+	l    sync.RWMutex
+	m    map[interface{}]int64
+}
+
+// NewCounting creates a new CountingSet of the given kind. It's populated with
+// the given items, counted as added once per occurrence. Unlike New, only
+// the items that don't match the kind are ignored, not all of them.
+func NewCounting(kind reflect.Kind, items ...interface{}) *CountingSet {
+	s := &CountingSet{kind: kind, m: make(map[interface{}]int64)}
+	for _, item := range items {
+		s.Add(item)
+	}
+	return s
+}
+
+// Kind returns the kind of the items the set holds.
+func (s *CountingSet) Kind() reflect.Kind {
+	return s.kind
+}
+
+// Add includes the specified items in the set, incrementing the count of
+// every item for every time it's passed.
+func (s *CountingSet) Add(items ...interface{}) error {
+	if err := typecheck(s.kind, items...); err != nil {
+		return err
+	}
+
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	for _, item := range items {
+		s.m[normalize(item)]++
+	}
+	return nil
+}
+
+// Remove deletes the specified items from the set, with their counts.
+func (s *CountingSet) Remove(items ...interface{}) error {
+	if err := typecheck(s.kind, items...); err != nil {
+		return err
+	}
+
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	for _, item := range items {
+		delete(s.m, normalize(item))
+	}
+	return nil
+}
+
+// Has looks for the existence of items passed. It returns false if nothing is
+// passed. For multiple items it returns true only if all of the items exist.
+func (s *CountingSet) Has(items ...interface{}) (bool, error) {
+	if len(items) == 0 {
+		return false, nil
+	}
+	if err := typecheck(s.kind, items...); err != nil {
+		return false, err
+	}
+
+	s.l.RLock()
+	defer s.l.RUnlock()
+
+	for _, item := range items {
+		if _, ok := s.m[normalize(item)]; !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Count returns the number of times item was added since it was last
+// removed. It returns zero for non members.
+func (s *CountingSet) Count(item interface{}) int64 {
+	s.l.RLock()
+	defer s.l.RUnlock()
+
+	return s.m[normalize(item)]
+}
+
+// Size returns the number of distinct items in the set.
+func (s *CountingSet) Size() int {
+	s.l.RLock()
+	defer s.l.RUnlock()
+
+	return len(s.m)
+}
+
+// List returns a slice of all items.
+func (s *CountingSet) List() []interface{} {
+	s.l.RLock()
+	defer s.l.RUnlock()
+
+	list := make([]interface{}, 0, len(s.m))
+	for item := range s.m {
+		list = append(list, item)
+	}
+	return list
+}
+
+// TopK returns the k most frequently added items, most frequent first. Items
+// with equal counts are ordered by value. It returns all items if there are
+// fewer than k.
+func (s *CountingSet) TopK(k int) []CountedItem {
+	s.l.RLock()
+	items := make([]CountedItem, 0, len(s.m))
+	for item, n := range s.m {
+		items = append(items, CountedItem{Item: item, Count: n})
+	}
+	s.l.RUnlock()
+
+	slices.SortFunc(items, func(a, b CountedItem) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return compare(a.Item, b.Item)
+	})
+	if k < len(items) {
+		items = items[:max(k, 0)]
+	}
+	return items
+}
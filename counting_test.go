@@ -0,0 +1,39 @@
+package goset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCountingSet(t *testing.T) {
+	s := NewCounting(reflect.String, "a", "b", "a", 1)
+	s.Add("c", "a", "b")
+
+	if s.Size() != 3 {
+		t.Errorf("CountingSet: expected three items, got %v", s.List())
+	}
+	if s.Count("a") != 3 || s.Count("b") != 2 || s.Count("x") != 0 {
+		t.Errorf("Count: unexpected counts %d, %d, %d", s.Count("a"), s.Count("b"), s.Count("x"))
+	}
+
+	expected := []CountedItem{{"a", 3}, {"b", 2}}
+	if top := s.TopK(2); !reflect.DeepEqual(top, expected) {
+		t.Errorf("TopK: expected %v, got %v", expected, top)
+	}
+	if len(s.TopK(10)) != 3 || len(s.TopK(0)) != 0 {
+		t.Error("TopK: unexpected number of items")
+	}
+
+	s.Remove("a")
+	if ok, _ := s.Has("a"); ok || s.Count("a") != 0 {
+		t.Error("Remove: the item and its count should be removed")
+	}
+	s.Add("a")
+	if s.Count("a") != 1 {
+		t.Error("Add: the count should restart after a removal")
+	}
+
+	if err := s.Add(1); err == nil {
+		t.Error("Add: items of another kind should return an error")
+	}
+}
@@ -0,0 +1,119 @@
+package goset
+
+import "context"
+
+// ctxCheckInterval is the number of items the context-aware operations
+// process between checks of their context.
+const ctxCheckInterval = 1024
+
+// UnionCtx is like Union, but it checks ctx periodically and returns its
+// error if it's done before the union is complete.
+func (s *Set) UnionCtx(ctx context.Context, t Interface) (*Set, error) {
+	if err := s.typematch(t); err != nil {
+		return nil, err
+	}
+	sets := []*Set{s, asSet(t)}
+	defer rlockAll(sets)()
+
+	u := NewWithOptions(s.kind, WithCapacity(len(s.m)))
+	n := 0
+	for _, set := range sets {
+		for item := range set.m {
+			if err := checkCtx(ctx, &n); err != nil {
+				return nil, err
+			}
+			u.insert(item)
+		}
+	}
+	return u, nil
+}
+
+// IntersectionCtx is like Intersection, but it checks ctx periodically and
+// returns its error if it's done before the intersection is complete.
+func (s *Set) IntersectionCtx(ctx context.Context, t Interface) (*Set, error) {
+	if err := s.typematch(t); err != nil {
+		return nil, err
+	}
+	small, large := s, asSet(t)
+	defer rlockAll([]*Set{small, large})()
+	if len(small.m) > len(large.m) {
+		small, large = large, small
+	}
+
+	u := New(s.kind)
+	n := 0
+	for item := range small.m {
+		if err := checkCtx(ctx, &n); err != nil {
+			return nil, err
+		}
+		if large.contains(item) {
+			u.insert(item)
+		}
+	}
+	return u, nil
+}
+
+// DifferenceCtx is like Difference, but it checks ctx periodically and
+// returns its error if it's done before the difference is complete.
+func (s *Set) DifferenceCtx(ctx context.Context, t Interface) (*Set, error) {
+	if err := s.typematch(t); err != nil {
+		return nil, err
+	}
+	other := asSet(t)
+	defer rlockAll([]*Set{s, other})()
+
+	u := New(s.kind)
+	n := 0
+	for item := range s.m {
+		if err := checkCtx(ctx, &n); err != nil {
+			return nil, err
+		}
+		if !other.contains(item) {
+			u.insert(item)
+		}
+	}
+	return u, nil
+}
+
+// AddCtx is like Add, but it inserts the items in batches, releasing the lock
+// of s in between, and returns the error of ctx if it's done before all items
+// are added. The items added until then are kept.
+func (s *Set) AddCtx(ctx context.Context, items ...interface{}) error {
+	if err := s.typecheck(items...); err != nil {
+		return err
+	}
+
+	for len(items) > 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		batch := items
+		if len(batch) > ctxCheckInterval {
+			batch = batch[:ctxCheckInterval]
+		}
+		items = items[len(batch):]
+
+		schedule("Add")
+		s.lock()
+		for _, item := range batch {
+			s.insert(item)
+		}
+		err := s.flushWAL()
+		s.unlock()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkCtx returns the error of ctx every ctxCheckInterval calls, counted by
+// n.
+func checkCtx(ctx context.Context, n *int) error {
+	*n++
+	if *n%ctxCheckInterval != 0 {
+		return nil
+	}
+	return ctx.Err()
+}
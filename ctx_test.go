@@ -0,0 +1,55 @@
+package goset
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestSet_Ctx(t *testing.T) {
+	ctx := context.Background()
+	s := New(reflect.Int, 1, 2, 3)
+	u := New(reflect.Int, 3, 4)
+
+	if v, err := s.UnionCtx(ctx, u); err != nil || !v.MustIsEqual(New(reflect.Int, 1, 2, 3, 4)) {
+		t.Errorf("UnionCtx: expected [1 2 3 4], got %v, %v", v, err)
+	}
+	if v, err := s.IntersectionCtx(ctx, u); err != nil || !v.MustIsEqual(New(reflect.Int, 3)) {
+		t.Errorf("IntersectionCtx: expected [3], got %v, %v", v, err)
+	}
+	if v, err := s.DifferenceCtx(ctx, u); err != nil || !v.MustIsEqual(New(reflect.Int, 1, 2)) {
+		t.Errorf("DifferenceCtx: expected [1 2], got %v, %v", v, err)
+	}
+	if _, err := s.UnionCtx(ctx, New(reflect.String)); err == nil {
+		t.Error("UnionCtx: sets of mismatched kinds should return an error")
+	}
+}
+
+func TestSet_Ctx_cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	items := make([]interface{}, 3*ctxCheckInterval)
+	for i := range items {
+		items[i] = i
+	}
+	s := New(reflect.Int, items...)
+
+	if _, err := s.UnionCtx(ctx, s); err != context.Canceled {
+		t.Errorf("UnionCtx: expected the error of the context, got %v", err)
+	}
+	if _, err := s.IntersectionCtx(ctx, s); err != context.Canceled {
+		t.Errorf("IntersectionCtx: expected the error of the context, got %v", err)
+	}
+	if _, err := s.DifferenceCtx(ctx, New(reflect.Int)); err != context.Canceled {
+		t.Errorf("DifferenceCtx: expected the error of the context, got %v", err)
+	}
+
+	u := New(reflect.Int)
+	if err := u.AddCtx(ctx, items...); err != context.Canceled || !u.IsEmpty() {
+		t.Errorf("AddCtx: expected the error of the context, got %v", err)
+	}
+	if err := u.AddCtx(context.Background(), items...); err != nil || u.Size() != len(items) {
+		t.Errorf("AddCtx: expected all items to be added, got %v", err)
+	}
+}
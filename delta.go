@@ -0,0 +1,113 @@
+package goset
+
+// Diff returns the changes that turn s into t: the items of t that are not in
+// s, and the items of s that are not in t. Both sets are read under a single
+// lock each.
+func (s *Set) Diff(t Interface) (added, removed *Set, err error) {
+	if err := s.typematch(t); err != nil {
+		return nil, nil, err
+	}
+	other := asSet(t)
+	defer rlockAll([]*Set{s, other})()
+
+	added, removed = New(s.kind), New(s.kind)
+	for item := range other.m {
+		if _, ok := s.m[item]; !ok {
+			added.insert(item)
+		}
+	}
+	for item := range s.m {
+		if _, ok := other.m[item]; !ok {
+			removed.insert(item)
+		}
+	}
+	return added, removed, nil
+}
+
+// Delta is a change of a set, as computed by DeltaTo. It's made of plain
+// slices so that it can be encoded and shipped to replicas, which apply it
+// with Apply.
+type Delta struct {
+	Added   []interface{}
+	Removed []interface{}
+}
+
+// IsEmpty reports whether d changes nothing.
+func (d Delta) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0
+}
+
+// DeltaTo returns the Delta that turns s into t, see Diff.
+func (s *Set) DeltaTo(t Interface) (Delta, error) {
+	added, removed, err := s.Diff(t)
+	if err != nil {
+		return Delta{}, err
+	}
+	return Delta{Added: added.sortedList(), Removed: removed.sortedList()}, nil
+}
+
+// Apply removes the items of d.Removed from s and adds the items of
+// d.Added, atomically under a single lock. If an item doesn't match the kind
+// of s, it returns an error and s is not modified.
+func (s *Set) Apply(d Delta) error {
+	if err := s.typecheck(d.Added...); err != nil {
+		return err
+	}
+	if err := s.typecheck(d.Removed...); err != nil {
+		return err
+	}
+
+	s.lock()
+	defer s.unlock()
+
+	for _, item := range d.Removed {
+		s.delete(item)
+	}
+	for _, item := range d.Added {
+		s.insert(item)
+	}
+	return s.flushWAL()
+}
+
+// BothChanged are the items changed the same way on both sides of a
+// three-way merge, see Merge3. They're agreements, not conflicts: Merge3 has
+// merged them already.
+type BothChanged struct {
+	Added   []interface{} // items added by both sides
+	Removed []interface{} // items removed by both sides
+}
+
+// Len returns the number of items changed on both sides.
+func (c BothChanged) Len() int {
+	return len(c.Added) + len(c.Removed)
+}
+
+// Merge3 merges the membership changes that ours and theirs made to their
+// common ancestor base: an item added or removed on either side is added to
+// or removed from the result. Since membership is binary, an item changed on
+// both sides was necessarily changed the same way, and it's merged as such.
+// Such items are reported in BothChanged, in ascending order, for callers
+// that want to know which changes were made twice. All sets must be of the
+// same kind.
+func Merge3(base, ours, theirs *Set) (*Set, BothChanged, error) {
+	if err := matchAll([]*Set{base, ours, theirs}); err != nil {
+		return nil, BothChanged{}, err
+	}
+
+	oursAdded, oursRemoved, _ := base.Diff(ours)
+	theirsAdded, theirsRemoved, _ := base.Diff(theirs)
+
+	merged := base.Copy()
+	merged.Apply(Delta{
+		Added:   append(oursAdded.List(), theirsAdded.List()...),
+		Removed: append(oursRemoved.List(), theirsRemoved.List()...),
+	})
+
+	bothAdded, _ := oursAdded.Intersection(theirsAdded)
+	bothRemoved, _ := oursRemoved.Intersection(theirsRemoved)
+	both := BothChanged{
+		Added:   bothAdded.sortedList(),
+		Removed: bothRemoved.sortedList(),
+	}
+	return merged, both, nil
+}
@@ -0,0 +1,90 @@
+package goset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSet_Diff(t *testing.T) {
+	actual := New(reflect.String, "a", "b", "c")
+	desired := New(reflect.String, "b", "c", "d", "e")
+
+	added, removed, err := actual.Diff(desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := added.IsEqual(New(reflect.String, "d", "e")); !ok {
+		t.Errorf("Diff: expected [d e] to be added, got %s", added)
+	}
+	if ok, _ := removed.IsEqual(New(reflect.String, "a")); !ok {
+		t.Errorf("Diff: expected [a] to be removed, got %s", removed)
+	}
+
+	added, removed, _ = actual.Diff(actual)
+	if !added.IsEmpty() || !removed.IsEmpty() {
+		t.Error("Diff: a set should not differ from itself")
+	}
+
+	if _, _, err := actual.Diff(New(reflect.Int)); err == nil {
+		t.Error("Diff: sets of mismatched kinds should return an error")
+	}
+}
+
+func TestSet_Apply(t *testing.T) {
+	primary := New(reflect.Int, 1, 2, 3)
+	replica := primary.Copy()
+
+	next := New(reflect.Int, 2, 3, 4)
+	d, err := primary.DeltaTo(next)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(d, Delta{Added: []interface{}{4}, Removed: []interface{}{1}}) {
+		t.Errorf("DeltaTo: unexpected delta %+v", d)
+	}
+
+	if err := replica.Apply(d); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := replica.IsEqual(next); !ok {
+		t.Errorf("Apply: expected [2 3 4], got %s", replica)
+	}
+
+	if d, _ := next.DeltaTo(replica); !d.IsEmpty() {
+		t.Errorf("DeltaTo: expected an empty delta, got %+v", d)
+	}
+
+	err = replica.Apply(Delta{Added: []interface{}{5}, Removed: []interface{}{"a"}})
+	if err == nil {
+		t.Error("Apply: items of another kind should return an error")
+	}
+	if ok, _ := replica.Has(5); ok {
+		t.Error("Apply: the set should not be modified on error")
+	}
+}
+
+func TestMerge3(t *testing.T) {
+	base := New(reflect.String, "a", "b", "c")
+	ours := New(reflect.String, "a", "b", "d", "x")
+	theirs := New(reflect.String, "b", "c", "d", "e")
+
+	merged, both, err := Merge3(base, ours, theirs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := merged.IsEqual(New(reflect.String, "b", "d", "e", "x")); !ok {
+		t.Errorf("Merge3: expected [b d e x], got %s", merged)
+	}
+
+	expected := BothChanged{Added: []interface{}{"d"}, Removed: []interface{}{}}
+	if !reflect.DeepEqual(both, expected) || both.Len() != 1 {
+		t.Errorf("Merge3: expected items changed on both sides %+v, got %+v", expected, both)
+	}
+	if base.Size() != 3 {
+		t.Error("Merge3: the base should not be modified")
+	}
+
+	if _, _, err := Merge3(base, ours, New(reflect.Int)); err == nil {
+		t.Error("Merge3: sets of mismatched kinds should return an error")
+	}
+}
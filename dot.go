@@ -0,0 +1,63 @@
+package goset
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// WriteDOT writes the given sets to w as an undirected Graphviz DOT graph:
+// every set and every member is a node, and every membership an edge between
+// them. Sets sharing members are also connected by a dashed edge labeled with
+// the number of shared members. Sets are labeled with their name, see
+// WithName, or with their position if they have none.
+func WriteDOT(w io.Writer, sets ...*Set) error {
+	var b strings.Builder
+	b.WriteString("graph sets {\n")
+	b.WriteString("\tnode [shape=ellipse];\n")
+
+	lists := make([][]interface{}, len(sets))
+	for i, s := range sets {
+		label := s.Name()
+		if label == "" {
+			label = fmt.Sprintf("set%d", i)
+		}
+		fmt.Fprintf(&b, "\ts%d [label=%s, shape=box];\n", i, strconv.Quote(label))
+		lists[i] = s.sortedList()
+	}
+
+	members := make(map[interface{}]int)
+	for _, list := range lists {
+		for _, item := range list {
+			if _, ok := members[item]; !ok {
+				members[item] = len(members)
+				fmt.Fprintf(&b, "\tm%d [label=%s];\n", members[item], strconv.Quote(fmt.Sprintf("%v", item)))
+			}
+		}
+	}
+
+	for i, list := range lists {
+		for _, item := range list {
+			fmt.Fprintf(&b, "\ts%d -- m%d;\n", i, members[item])
+		}
+	}
+
+	for i := range sets {
+		for j := i + 1; j < len(sets); j++ {
+			shared := 0
+			for _, item := range lists[i] {
+				if ok, _ := sets[j].Has(item); ok {
+					shared++
+				}
+			}
+			if shared > 0 {
+				fmt.Fprintf(&b, "\ts%d -- s%d [style=dashed, label=\"%d shared\"];\n", i, j, shared)
+			}
+		}
+	}
+
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
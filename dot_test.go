@@ -0,0 +1,35 @@
+package goset
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWriteDOT(t *testing.T) {
+	admins := NewWithOptions(reflect.String, WithName("admins"), WithItems("alice", "bob"))
+	users := New(reflect.String, "bob", "carol")
+
+	var b strings.Builder
+	if err := WriteDOT(&b, admins, users); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `graph sets {
+	node [shape=ellipse];
+	s0 [label="admins", shape=box];
+	s1 [label="set1", shape=box];
+	m0 [label="alice"];
+	m1 [label="bob"];
+	m2 [label="carol"];
+	s0 -- m0;
+	s0 -- m1;
+	s1 -- m1;
+	s1 -- m2;
+	s0 -- s1 [style=dashed, label="1 shared"];
+}
+`
+	if b.String() != expected {
+		t.Errorf("WriteDOT: expected\n%s\ngot\n%s", expected, b.String())
+	}
+}
@@ -0,0 +1,81 @@
+package goset
+
+import (
+	"container/heap"
+	"context"
+)
+
+// Drain repeatedly removes the highest priority item of s, the one that is
+// less than all others according to less, and passes it to f, until s is
+// empty or ctx is done. Items added to s while it's drained are processed
+// too, in priority order.
+//
+// The items are kept in a heap, so draining n items takes O(n log n) calls of
+// less. The heap is rebuilt in O(n) whenever s is modified by something else
+// than Drain in between two items.
+//
+// If f returns an error, the item is added back to s and Drain returns the
+// error. If ctx is done, Drain returns its error.
+func (s *Set) Drain(ctx context.Context, less func(a, b interface{}) bool, f func(item interface{}) error) error {
+	h := &drainHeap{less: less}
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		item, ok, err := s.popMin(h)
+		if err != nil || !ok {
+			return err
+		}
+		if err := f(item); err != nil {
+			s.Add(item)
+			return err
+		}
+	}
+}
+
+// popMin removes the item of s that is less than all others according to the
+// heap h and returns it. The returned bool is false if the set is empty. The
+// error is the failure of the write-ahead log of s, if any.
+func (s *Set) popMin(h *drainHeap) (interface{}, bool, error) {
+	s.lock()
+	defer s.unlock()
+
+	if !h.built || h.version != s.version {
+		h.items = h.items[:0]
+		for item := range s.m {
+			h.items = append(h.items, item)
+		}
+		heap.Init(h)
+		h.version, h.built = s.version, true
+	}
+
+	for h.Len() > 0 {
+		item := heap.Pop(h)
+		if s.delete(item) {
+			h.version = s.version
+			return item, true, s.flushWAL()
+		}
+	}
+	return nil, false, nil
+}
+
+// drainHeap is a heap of the items of a set being drained, valid as long as
+// the version of the set is version.
+type drainHeap struct {
+	less    func(a, b interface{}) bool
+	items   []interface{}
+	version uint64
+	built   bool
+}
+
+func (h *drainHeap) Len() int           { return len(h.items) }
+func (h *drainHeap) Less(i, j int) bool { return h.less(h.items[i], h.items[j]) }
+func (h *drainHeap) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *drainHeap) Push(x interface{}) { h.items = append(h.items, x) }
+
+func (h *drainHeap) Pop() interface{} {
+	item := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return item
+}
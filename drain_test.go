@@ -0,0 +1,66 @@
+package goset
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSet_Drain(t *testing.T) {
+	s := New(reflect.Int, 3, 1, 2)
+	less := func(a, b interface{}) bool { return a.(int) < b.(int) }
+
+	var order []int
+	err := s.Drain(context.Background(), less, func(item interface{}) error {
+		order = append(order, item.(int))
+		if item == 1 {
+			s.Add(0, 4)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(order, []int{1, 0, 2, 3, 4}) || !s.IsEmpty() {
+		t.Errorf("Drain: unexpected order %v", order)
+	}
+
+	failed := errors.New("failed")
+	s.Add(1, 2)
+	err = s.Drain(context.Background(), less, func(item interface{}) error {
+		return failed
+	})
+	if err != failed {
+		t.Errorf("Drain: expected the error of f, got %v", err)
+	}
+	if s.Size() != 2 {
+		t.Error("Drain: the item that failed should be added back")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := s.Drain(ctx, less, func(interface{}) error { return nil }); err != context.Canceled || s.Size() != 2 {
+		t.Errorf("Drain: a done context should stop the drain, got %v", err)
+	}
+}
+
+func TestSet_DrainComparisons(t *testing.T) {
+	const n = 1000
+
+	s := New(reflect.Int)
+	for i := 0; i < n; i++ {
+		s.Add(i)
+	}
+
+	calls := 0
+	less := func(a, b interface{}) bool {
+		calls++
+		return a.(int) < b.(int)
+	}
+	s.Drain(context.Background(), less, func(interface{}) error { return nil })
+
+	if calls > 50*n {
+		t.Errorf("Drain: expected O(n log n) comparisons, got %d for %d items", calls, n)
+	}
+}
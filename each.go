@@ -0,0 +1,50 @@
+package goset
+
+import "fmt"
+
+// ConcurrentModificationError is returned by Each when s was modified while
+// it was being iterated over.
+type ConcurrentModificationError struct {
+	Visited int // number of items passed to fn before the modification was detected
+}
+
+func (e *ConcurrentModificationError) Error() string {
+	return fmt.Sprintf("goset: set modified during iteration after %d items", e.Visited)
+}
+
+// Version returns the number of changes made to s, each item added or
+// removed counting as one change. Two equal versions of a set guarantee it
+// wasn't modified in between.
+func (s *Set) Version() uint64 {
+	s.rlock()
+	defer s.l.RUnlock()
+
+	return s.version
+}
+
+// Each calls fn for every item of s, until fn returns false. It doesn't copy
+// the items and doesn't hold the lock of s while fn runs, so fn may use s. If
+// s is modified during the iteration, by fn or by another goroutine, Each
+// stops and returns a *ConcurrentModificationError rather than passing on
+// items of an inconsistent state.
+func (s *Set) Each(fn func(item interface{}) bool) error {
+	s.rlock()
+	version := s.version
+	visited := 0
+	for item := range s.m {
+		s.l.RUnlock()
+		more := fn(item)
+		visited++
+		s.rlock()
+
+		if s.version != version {
+			s.l.RUnlock()
+			return &ConcurrentModificationError{Visited: visited}
+		}
+		if !more {
+			break
+		}
+	}
+	s.l.RUnlock()
+	return nil
+}
@@ -0,0 +1,71 @@
+package goset
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSet_Each(t *testing.T) {
+	s := New(reflect.Int, 1, 2, 3)
+
+	sum := 0
+	err := s.Each(func(item interface{}) bool {
+		sum += item.(int)
+		return true
+	})
+	if err != nil || sum != 6 {
+		t.Errorf("Each: expected a sum of 6, got %d, %v", sum, err)
+	}
+
+	calls := 0
+	s.Each(func(item interface{}) bool {
+		calls++
+		return false
+	})
+	if calls != 1 {
+		t.Errorf("Each: should stop when fn returns false, got %d calls", calls)
+	}
+
+	err = s.Each(func(item interface{}) bool {
+		s.Add(item.(int) + 10)
+		return true
+	})
+	var cme *ConcurrentModificationError
+	if !errors.As(err, &cme) || cme.Visited != 1 {
+		t.Errorf("Each: expected a modification to be detected after one item, got %v", err)
+	}
+
+	err = s.Each(func(item interface{}) bool {
+		s.Has(item)
+		s.Add(item)
+		return true
+	})
+	if err != nil {
+		t.Errorf("Each: operations that don't change the set should be allowed, got %v", err)
+	}
+}
+
+func TestSet_Version(t *testing.T) {
+	s := New(reflect.Int)
+	v := s.Version()
+
+	s.Add(1)
+	s.Add(1)
+	s.Remove(2)
+	if s.Version() != v+1 {
+		t.Errorf("Version: expected a single change, got %d", s.Version()-v)
+	}
+
+	s.Clear()
+	s.Clear()
+	if s.Version() != v+2 {
+		t.Errorf("Version: expected two changes, got %d", s.Version()-v)
+	}
+
+	s.Add(1, 2, 3)
+	s.Clear()
+	if s.Version() != v+8 {
+		t.Errorf("Version: Clear should count every removed item, got %d changes", s.Version()-v)
+	}
+}
@@ -0,0 +1,62 @@
+package goset
+
+import "reflect"
+
+// ExcludingSet is a live, read-only view of a set with the members of an
+// exclusion set hidden. Both sets are consulted on every call, so changes to
+// either are visible immediately without materializing the difference.
+type ExcludingSet struct {
+	base    *Set
+	exclude *Set
+}
+
+// Excluding returns a view of s in which the members of other are hidden.
+func (s *Set) Excluding(other *Set) *ExcludingSet {
+	return &ExcludingSet{base: s, exclude: other}
+}
+
+// Kind returns the kind of the items the view holds.
+func (e *ExcludingSet) Kind() reflect.Kind {
+	return e.base.Kind()
+}
+
+// Has looks for the existence of items passed in the view. It returns false
+// if nothing is passed. For multiple items it returns true only if all of the
+// items exist in the base set and none of them is excluded.
+func (e *ExcludingSet) Has(items ...interface{}) (bool, error) {
+	ok, err := e.base.Has(items...)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	e.exclude.rlock()
+	defer e.exclude.l.RUnlock()
+
+	for _, item := range items {
+		if e.exclude.contains(item) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// List returns a slice of the items of the base set that are not excluded.
+func (e *ExcludingSet) List() []interface{} {
+	list := e.base.List()
+
+	e.exclude.rlock()
+	defer e.exclude.l.RUnlock()
+
+	visible := list[:0]
+	for _, item := range list {
+		if !e.exclude.contains(item) {
+			visible = append(visible, item)
+		}
+	}
+	return visible
+}
+
+// Size returns the number of visible items.
+func (e *ExcludingSet) Size() int {
+	return len(e.List())
+}
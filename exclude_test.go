@@ -0,0 +1,35 @@
+package goset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSet_Excluding(t *testing.T) {
+	s := New(reflect.String, "a", "b", "c")
+	deny := New(reflect.String, "b")
+	v := s.Excluding(deny)
+
+	if ok, _ := v.Has("a", "c"); !ok {
+		t.Error("Excluding: non excluded items should be visible")
+	}
+	if ok, _ := v.Has("a", "b"); ok {
+		t.Error("Excluding: excluded items should be hidden")
+	}
+	if v.Size() != 2 {
+		t.Errorf("Excluding: expected two items, got %v", v.List())
+	}
+
+	deny.Add("a")
+	s.Add("d")
+	if ok, _ := v.Has("c", "d"); !ok || v.Size() != 2 {
+		t.Errorf("Excluding: the view should be live, got %v", v.List())
+	}
+	if s.Size() != 4 {
+		t.Error("Excluding: the base set should not be modified")
+	}
+
+	if _, err := v.Has(1); err == nil {
+		t.Error("Excluding: items of another kind should return an error")
+	}
+}
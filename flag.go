@@ -0,0 +1,53 @@
+package goset
+
+import (
+	"flag"
+	"reflect"
+)
+
+var _ flag.Value = (*Set)(nil)
+
+// Set implements the flag.Value interface. The value is split on the flag
+// separator, see WithFlagSeparator, and every element is converted to the kind
+// of the set and added to it. The flag can be repeated, each occurrence adds to
+// the items of the previous ones. Surrounding whitespace and empty elements
+// are ignored.
+//
+// A zero Set can be used as a flag directly, it holds strings and splits
+// values on commas:
+//
+//	var tags goset.Set
+//	flag.Var(&tags, "tags", "comma separated list of tags")
+func (s *Set) Set(value string) error {
+	sep := s.flagSep
+	switch {
+	case s.flagNoSplit:
+		sep = ""
+	case sep == "":
+		sep = ","
+	}
+
+	kind := s.Kind()
+	if kind == reflect.Invalid {
+		kind = reflect.String
+	}
+
+	items, err := parseItems(value, sep, kind)
+	if err != nil {
+		return err
+	}
+
+	s.lock()
+	defer s.unlock()
+
+	if s.m == nil {
+		s.m = make(map[interface{}]struct{}, len(items))
+	}
+	if s.kind == reflect.Invalid {
+		s.kind = kind
+	}
+	for _, item := range items {
+		s.insert(item)
+	}
+	return s.flushWAL()
+}
@@ -0,0 +1,51 @@
+package goset
+
+import (
+	"flag"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestSet_Set(t *testing.T) {
+	var tags Set
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(&tags, "tags", "")
+	if err := fs.Parse([]string{"-tags=a,b", "-tags", " c ,,a"}); err != nil {
+		t.Fatal(err)
+	}
+	if tags.Kind() != reflect.String {
+		t.Errorf("Set: a zero set should hold strings, got %s", tags.Kind())
+	}
+	if ok, _ := tags.IsEqual(New(reflect.String, "a", "b", "c")); !ok {
+		t.Errorf("Set: expected [a b c], got %s", &tags)
+	}
+
+	ports := New(reflect.Int)
+	if err := ports.Set("80,443"); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := ports.Has(80, 443); !ok {
+		t.Errorf("Set: values should be converted to the kind of the set, got %s", ports)
+	}
+	if err := ports.Set("http"); err == nil {
+		t.Error("Set: values that can't be converted should return an error")
+	}
+}
+
+func TestWithFlagSeparator(t *testing.T) {
+	s := NewWithOptions(reflect.String, WithFlagSeparator(";"))
+	s.Set("a,b;c")
+	if ok, _ := s.Has("a,b", "c"); !ok || s.Size() != 2 {
+		t.Errorf("WithFlagSeparator: expected [a,b c], got %s", s)
+	}
+
+	s = NewWithOptions(reflect.String, WithFlagSeparator(""))
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(s, "name", "")
+	fs.Parse([]string{"-name", "a,b", "-name", "c"})
+	if ok, _ := s.Has("a,b", "c"); !ok || s.Size() != 2 {
+		t.Errorf("WithFlagSeparator: an empty separator should disable splitting, got %s", s)
+	}
+}
@@ -0,0 +1,104 @@
+package goset
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ForEachMember runs worker in its own goroutine for every member of s,
+// until ctx is done. Workers are started for the items added to s while
+// ForEachMember runs, and the context passed to a worker is cancelled when
+// its item is removed from s. Once ctx is done, all workers are cancelled and
+// waited for.
+//
+// A worker that returns while its item is still a member is not restarted,
+// unless the item is removed and added again. ForEachMember returns the
+// errors of the workers joined with errors.Join, except the ones that only
+// report the cancellation of their context.
+func ForEachMember(ctx context.Context, s *Set, worker func(ctx context.Context, item interface{}) error) error {
+	g := &memberGroup{
+		ctx:     ctx,
+		worker:  worker,
+		running: make(map[interface{}]context.CancelFunc),
+	}
+
+	cancel := s.observe(&observer{fn: func(ev ChangeEvent) {
+		g.reconcile(s, ev.Items)
+	}})
+	g.reconcile(s, s.List())
+
+	<-ctx.Done()
+	cancel()
+	g.stop()
+	return errors.Join(g.errs...)
+}
+
+// memberGroup holds the workers started by ForEachMember.
+type memberGroup struct {
+	ctx    context.Context
+	worker func(ctx context.Context, item interface{}) error
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	running map[interface{}]context.CancelFunc
+	stopped bool
+	errs    []error
+}
+
+// reconcile starts or cancels the workers of items to match their current
+// membership in s. Events of concurrent operations can be delivered in any
+// order, so they're only used as a hint of which items to check.
+func (g *memberGroup) reconcile(s *Set, items []interface{}) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.stopped {
+		return
+	}
+
+	for _, item := range items {
+		cancel, running := g.running[item]
+		member, _ := s.Has(item)
+		switch {
+		case member && !running:
+			g.start(item)
+		case !member && running:
+			cancel()
+			delete(g.running, item)
+		}
+	}
+}
+
+// start runs the worker of item. g.mu must be held.
+func (g *memberGroup) start(item interface{}) {
+	ctx, cancel := context.WithCancel(g.ctx)
+	g.running[item] = cancel
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		defer cancel()
+
+		err := g.worker(ctx, item)
+		if err == nil || ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+			return
+		}
+
+		g.mu.Lock()
+		g.errs = append(g.errs, err)
+		g.mu.Unlock()
+	}()
+}
+
+// stop cancels all workers and waits for them to return.
+func (g *memberGroup) stop() {
+	g.mu.Lock()
+	g.stopped = true
+	for _, cancel := range g.running {
+		cancel()
+	}
+	g.mu.Unlock()
+
+	g.wg.Wait()
+}
@@ -0,0 +1,84 @@
+package goset_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fatih/goset"
+	"github.com/fatih/goset/settest"
+)
+
+func TestForEachMember(t *testing.T) {
+	s := goset.New(reflect.String, "a", "b")
+	settest.VerifyNoLeaks(t, s)
+
+	var (
+		mu      sync.Mutex
+		active  = make(map[interface{}]bool)
+		changed = make(chan struct{}, 100)
+	)
+	worker := func(ctx context.Context, item interface{}) error {
+		mu.Lock()
+		active[item] = true
+		mu.Unlock()
+		changed <- struct{}{}
+
+		<-ctx.Done()
+
+		mu.Lock()
+		delete(active, item)
+		mu.Unlock()
+		changed <- struct{}{}
+
+		if item == "c" {
+			return errors.New("c failed")
+		}
+		return ctx.Err()
+	}
+
+	waitFor := func(expected ...string) {
+		t.Helper()
+		timeout := time.After(time.Second)
+		for {
+			mu.Lock()
+			ok := len(active) == len(expected)
+			for _, item := range expected {
+				ok = ok && active[item]
+			}
+			mu.Unlock()
+			if ok {
+				return
+			}
+
+			select {
+			case <-changed:
+			case <-timeout:
+				t.Fatalf("ForEachMember: expected workers for %v, got %v", expected, active)
+			}
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- goset.ForEachMember(ctx, s, worker) }()
+
+	waitFor("a", "b")
+
+	s.Add("c")
+	waitFor("a", "b", "c")
+
+	s.Remove("a")
+	waitFor("b", "c")
+
+	cancel()
+	err := <-done
+	waitFor()
+
+	if err == nil || err.Error() != "c failed" {
+		t.Errorf("ForEachMember: expected the error of c only, got %v", err)
+	}
+}
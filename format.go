@@ -0,0 +1,90 @@
+package goset
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+var _ fmt.Formatter = (*Set)(nil)
+
+// Format implements fmt.Formatter:
+//
+//	%v, %s   the compact form of String, such as [1, 2, 3]
+//	%+v      the compact form with the kind and size, [1, 2, 3] (kind int, size 3)
+//	%#v      a Go expression creating the set, goset.New(reflect.Int, 1, 2, 3)
+//	%q       the compact form quoted
+//
+// The items are sorted for %+v and %#v, and converted to their type for %#v
+// where needed, as in goset.New(reflect.Int64, int64(1)). Other verbs are
+// reported as bad verbs like fmt does.
+func (s *Set) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		items := s.sortedList()
+		args := make([]string, 0, len(items)+1)
+		args = append(args, "reflect."+kindName(s.kind))
+		for _, item := range items {
+			args = append(args, goLiteral(item))
+		}
+		fmt.Fprintf(f, "goset.New(%s)", strings.Join(args, ", "))
+	case verb == 'v' && f.Flag('+'):
+		items := s.sortedList()
+		fmt.Fprintf(f, "%s (kind %s, size %d)", formatList(items), s.kind, len(items))
+	case verb == 'v' || verb == 's':
+		fmt.Fprint(f, s.String())
+	case verb == 'q':
+		fmt.Fprintf(f, "%q", s.String())
+	default:
+		fmt.Fprintf(f, "%%!%c(*goset.Set=%s)", verb, s.String())
+	}
+}
+
+// goLiteral returns a Go expression for item as printed by %#v. Items of basic
+// kinds are converted to their type, such as int64(1), unless they're of the
+// default type of the constant already, so that the expression has the kind of
+// the set.
+func goLiteral(item interface{}) string {
+	v := reflect.ValueOf(item)
+	lit := fmt.Sprintf("%#v", item)
+	if k := v.Kind(); k == reflect.Float32 || k == reflect.Float64 {
+		switch f := v.Float(); {
+		case math.IsInf(f, 1):
+			lit = "math.Inf(1)"
+		case math.IsInf(f, -1):
+			lit = "math.Inf(-1)"
+		case math.IsNaN(f):
+			lit = "math.NaN()"
+		}
+	}
+
+	t := v.Type()
+	if t.PkgPath() == "" {
+		switch t.Kind() {
+		case reflect.Int, reflect.String, reflect.Bool, reflect.Complex128:
+			return lit
+		case reflect.Float64:
+			if strings.ContainsAny(lit, ".e") {
+				return lit
+			}
+		}
+	}
+	if k := t.Kind(); k >= reflect.Bool && k <= reflect.Complex128 || k == reflect.String {
+		return t.String() + "(" + lit + ")"
+	}
+	return lit
+}
+
+// kindName returns the name of the reflect constant of kind, such as Int for
+// reflect.Int.
+func kindName(kind reflect.Kind) string {
+	switch kind {
+	case reflect.Pointer:
+		return "Pointer"
+	case reflect.UnsafePointer:
+		return "UnsafePointer"
+	}
+	name := kind.String()
+	return strings.ToUpper(name[:1]) + name[1:]
+}
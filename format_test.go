@@ -0,0 +1,122 @@
+package goset
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"testing"
+)
+
+func TestSet_Format(t *testing.T) {
+	s := NewWithOptions(reflect.Int, WithSortedOutput(), WithItems(3, 1, 2))
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"%+v", "[1, 2, 3] (kind int, size 3)"},
+		{"%#v", "goset.New(reflect.Int, 1, 2, 3)"},
+		{"%d", "%!d(*goset.Set=" + s.String() + ")"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprintf(tt.format, s); got != tt.expected {
+			t.Errorf("Format(%s): expected %s, got %s", tt.format, tt.expected, got)
+		}
+	}
+
+	if got := fmt.Sprintf("%v", s); got != s.String() {
+		t.Errorf("Format(%%v): expected %s, got %s", s.String(), got)
+	}
+	if got := fmt.Sprintf("%s", s); got != s.String() {
+		t.Errorf("Format(%%s): expected %s, got %s", s.String(), got)
+	}
+
+	w := New(reflect.String, "a")
+	if got := fmt.Sprintf("%#v", w); got != `goset.New(reflect.String, "a")` {
+		t.Errorf("Format(%%#v): unexpected %s", got)
+	}
+	if got := fmt.Sprintf("%q", w); got != `"[a]"` {
+		t.Errorf("Format(%%q): unexpected %s", got)
+	}
+}
+
+// TestSet_Format_goSyntax evaluates the items printed by %#v for every
+// numeric kind and checks that they make up the same set again.
+func TestSet_Format_goSyntax(t *testing.T) {
+	sets := []*Set{
+		New(reflect.Int, 1, -2),
+		New(reflect.Int8, int8(1), int8(-2)),
+		New(reflect.Int16, int16(1), int16(-2)),
+		New(reflect.Int32, int32(1), int32(-2)),
+		New(reflect.Int64, int64(1), int64(-2)),
+		New(reflect.Uint, uint(1), uint(2)),
+		New(reflect.Uint8, uint8(1), uint8(2)),
+		New(reflect.Uint16, uint16(1), uint16(2)),
+		New(reflect.Uint32, uint32(1), uint32(2)),
+		New(reflect.Uint64, uint64(1), uint64(2)),
+		New(reflect.Uintptr, uintptr(1), uintptr(2)),
+		New(reflect.Float32, float32(1), float32(2.5)),
+		New(reflect.Float64, 1.0, 2.5, 1e30),
+		New(reflect.Complex64, complex64(1), complex64(2+3i)),
+		New(reflect.Complex128, complex128(1), 2+3i),
+	}
+	for _, s := range sets {
+		src := fmt.Sprintf("%#v", s)
+		call, err := parser.ParseExpr(src)
+		if err != nil {
+			t.Fatalf("Format(%%#v): %s doesn't parse: %s", src, err)
+		}
+
+		r := New(s.Kind())
+		for _, arg := range call.(*ast.CallExpr).Args[1:] {
+			if err := r.Add(evalConst(t, types.ExprString(arg))); err != nil {
+				t.Errorf("Format(%%#v): %s doesn't evaluate to the set: %s", src, err)
+			}
+		}
+		if ok, _ := r.IsEqual(s); !ok {
+			t.Errorf("Format(%%#v): %s evaluates to %s", src, r)
+		}
+	}
+}
+
+// evalConst evaluates the constant expression src to a value of its type.
+func evalConst(t *testing.T, src string) interface{} {
+	t.Helper()
+
+	tv, err := types.Eval(token.NewFileSet(), nil, token.NoPos, src)
+	if err != nil {
+		t.Fatalf("evaluating %s: %s", src, err)
+	}
+	zeros := map[string]interface{}{
+		"int": 0, "int8": int8(0), "int16": int16(0), "int32": int32(0), "int64": int64(0),
+		"uint": uint(0), "uint8": uint8(0), "uint16": uint16(0), "uint32": uint32(0), "uint64": uint64(0),
+		"uintptr": uintptr(0), "float32": float32(0), "float64": 0.0,
+		"complex64": complex64(0), "complex128": complex128(0),
+	}
+	zero, ok := zeros[types.Default(tv.Type).String()]
+	if !ok {
+		t.Fatalf("evaluating %s: unexpected type %s", src, tv.Type)
+	}
+
+	v := reflect.New(reflect.TypeOf(zero)).Elem()
+	switch val := tv.Value; {
+	case v.CanInt():
+		i, _ := constant.Int64Val(constant.ToInt(val))
+		v.SetInt(i)
+	case v.CanUint():
+		u, _ := constant.Uint64Val(constant.ToInt(val))
+		v.SetUint(u)
+	case v.CanFloat():
+		f, _ := constant.Float64Val(constant.ToFloat(val))
+		v.SetFloat(f)
+	case v.CanComplex():
+		re, _ := constant.Float64Val(constant.Real(val))
+		im, _ := constant.Float64Val(constant.Imag(val))
+		v.SetComplex(complex(re, im))
+	}
+	return v.Interface()
+}
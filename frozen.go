@@ -0,0 +1,90 @@
+package goset
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FrozenSet is an immutable view of a Set at a point in time, see
+// Set.Snapshot. As it never changes it doesn't need any locking, and a
+// sequence of calls always observes the same items.
+type FrozenSet struct {
+	m    map[interface{}]struct{}
+	kind reflect.Kind
+}
+
+// Snapshot returns an immutable view of the current items of s. Taking a
+// snapshot doesn't copy anything: the items are shared with s until s is
+// modified for the first time, which copies them once (copy-on-write).
+func (s *Set) Snapshot() *FrozenSet {
+	s.lock()
+	defer s.l.Unlock()
+
+	s.shared = true
+	return &FrozenSet{m: s.m, kind: s.kind}
+}
+
+// unshare gives s its own copy of the items if they're shared with a
+// FrozenSet. The write lock must be held.
+func (s *Set) unshare() {
+	if s.shared {
+		s.rebuild(len(s.m))
+	}
+}
+
+// Kind returns the kind of the items the set holds.
+func (f *FrozenSet) Kind() reflect.Kind {
+	return f.kind
+}
+
+// Has looks for the existence of items passed. It returns false if nothing is
+// passed. For multiple items it returns true only if all of the items exist.
+// Items of another kind are reported missing.
+func (f *FrozenSet) Has(items ...interface{}) bool {
+	if len(items) == 0 {
+		return false
+	}
+	for _, item := range items {
+		if !accepts(f.kind, item) {
+			return false
+		}
+		if _, ok := f.m[normalize(item)]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Size returns the number of items in the set.
+func (f *FrozenSet) Size() int {
+	return len(f.m)
+}
+
+// IsEmpty checks for emptiness of the set.
+func (f *FrozenSet) IsEmpty() bool {
+	return f.Size() == 0
+}
+
+// List returns a slice of all items.
+func (f *FrozenSet) List() []interface{} {
+	list := make([]interface{}, 0, len(f.m))
+	for item := range f.m {
+		list = append(list, item)
+	}
+	return list
+}
+
+// String representation of f.
+func (f *FrozenSet) String() string {
+	t := make([]string, 0, len(f.m))
+	for item := range f.m {
+		t = append(t, fmt.Sprintf("%v", item))
+	}
+	return fmt.Sprintf("[%s]", strings.Join(t, ", "))
+}
+
+// Thaw returns a new, mutable Set with the items of f.
+func (f *FrozenSet) Thaw() *Set {
+	return New(f.kind, f.List()...)
+}
@@ -0,0 +1,42 @@
+package goset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSet_Snapshot(t *testing.T) {
+	s := New(reflect.String, "1", "2", "3")
+	f := s.Snapshot()
+
+	s.Add("4")
+	s.Remove("1")
+	g := s.Snapshot()
+	s.Clear()
+
+	if f.Size() != 3 || !f.Has("1", "2", "3") || f.Has("4") {
+		t.Errorf("Snapshot: first snapshot should be [1 2 3], got %s", f)
+	}
+
+	if g.Size() != 3 || !g.Has("2", "3", "4") || g.Has("1") {
+		t.Errorf("Snapshot: second snapshot should be [2 3 4], got %s", g)
+	}
+
+	if !s.IsEmpty() {
+		t.Error("Snapshot: the set should be empty after Clear")
+	}
+
+	if f.Has(1) || f.Has() {
+		t.Error("Snapshot: items of another kind or no items should be reported missing")
+	}
+}
+
+func TestFrozenSet_Thaw(t *testing.T) {
+	s := New(reflect.Int, 1, 2)
+	u := s.Snapshot().Thaw()
+	u.Add(3)
+
+	if s.Size() != 2 || u.Size() != 3 {
+		t.Error("Thaw: the thawed set should be independent of the original set")
+	}
+}
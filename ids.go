@@ -0,0 +1,29 @@
+package goset
+
+// IDOf returns the stable ID assigned to item by a set created with the
+// WithIDs option. The returned bool is false if item has never been a member
+// of the set or the set doesn't assign IDs.
+func (s *Set) IDOf(item interface{}) (int, bool) {
+	if !s.accepts(item) {
+		return 0, false
+	}
+
+	s.rlock()
+	defer s.l.RUnlock()
+
+	id, ok := s.ids[normalize(item)]
+	return id, ok
+}
+
+// ItemOf returns the item with the given ID, the reverse of IDOf. The returned
+// bool is false if no item has been assigned id. The item is returned even if
+// it's no longer a member of the set.
+func (s *Set) ItemOf(id int) (interface{}, bool) {
+	s.rlock()
+	defer s.l.RUnlock()
+
+	if id < 0 || id >= len(s.items) {
+		return nil, false
+	}
+	return s.items[id], true
+}
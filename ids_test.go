@@ -0,0 +1,57 @@
+package goset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSet_IDOf(t *testing.T) {
+	s := NewWithOptions(reflect.String, WithIDs(), WithItems("a"))
+	s.Add("b", "c", "a")
+
+	for i, item := range []string{"a", "b", "c"} {
+		id, ok := s.IDOf(item)
+		if !ok {
+			t.Fatalf("IDOf: item %s should have an ID", item)
+		}
+		if id != i {
+			t.Errorf("IDOf: item %s should have ID %d, got %d", item, i, id)
+		}
+		if back, _ := s.ItemOf(id); back != item {
+			t.Errorf("ItemOf: ID %d should map back to %s, got %v", id, item, back)
+		}
+	}
+
+	if _, ok := s.IDOf("d"); ok {
+		t.Error("IDOf: an unknown item should not have an ID")
+	}
+
+	if _, ok := s.ItemOf(3); ok {
+		t.Error("ItemOf: an unassigned ID should not map to an item")
+	}
+}
+
+func TestSet_IDOf_stable(t *testing.T) {
+	s := NewWithOptions(reflect.Int, WithIDs(), WithItems(1, 2))
+
+	before, _ := s.IDOf(2)
+	s.Remove(2)
+	s.Add(3)
+	s.Add(2)
+
+	if after, _ := s.IDOf(2); after != before {
+		t.Errorf("IDOf: re-added item should keep ID %d, got %d", before, after)
+	}
+
+	if id, _ := s.IDOf(3); id != 2 {
+		t.Errorf("IDOf: IDs should not be reused, expected 2 got %d", id)
+	}
+}
+
+func TestSet_IDOf_disabled(t *testing.T) {
+	s := New(reflect.Int, 1)
+
+	if _, ok := s.IDOf(1); ok {
+		t.Error("IDOf: a set without WithIDs should not assign IDs")
+	}
+}
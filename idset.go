@@ -0,0 +1,218 @@
+package goset
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ID is a 16 byte identifier such as a UUID or a ULID.
+type ID [16]byte
+
+// String returns the canonical UUID representation of id,
+// xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx.
+func (id ID) String() string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], id[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], id[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], id[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], id[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], id[10:])
+	return string(buf[:])
+}
+
+// ParseUUID parses a UUID in its canonical form, with or without dashes.
+func ParseUUID(s string) (ID, error) {
+	var id ID
+	raw := strings.ReplaceAll(s, "-", "")
+	if len(raw) != 32 || (len(s) != 32 && (len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-')) {
+		return id, fmt.Errorf("invalid UUID %q", s)
+	}
+	if _, err := hex.Decode(id[:], []byte(raw)); err != nil {
+		return id, fmt.Errorf("invalid UUID %q: %s", s, err)
+	}
+	return id, nil
+}
+
+// crockford is the base32 alphabet of ULIDs.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ParseULID parses a ULID, 26 characters of Crockford's base32, case
+// insensitive.
+func ParseULID(s string) (ID, error) {
+	var id ID
+	if len(s) != 26 || strings.IndexByte("01234567", s[0]) < 0 {
+		return id, fmt.Errorf("invalid ULID %q", s)
+	}
+
+	// accumulate the 130 bits of the text, the first two are always zero
+	var bits uint64
+	n, j := 0, 0
+	for i := 0; i < len(s); i++ {
+		v := strings.IndexByte(crockford, upper(s[i]))
+		if v < 0 {
+			return id, fmt.Errorf("invalid ULID %q", s)
+		}
+		bits = bits<<5 | uint64(v)
+		n += 5
+		if i == 0 {
+			n -= 2
+		}
+		for n >= 8 {
+			n -= 8
+			id[j] = byte(bits >> uint(n))
+			j++
+		}
+	}
+	return id, nil
+}
+
+// ULID returns the ULID representation of id.
+func (id ID) ULID() string {
+	var buf [26]byte
+	var bits uint64
+	n, j := 2, 0 // two leading zero bits
+	for _, b := range id {
+		bits = bits<<8 | uint64(b)
+		n += 8
+		for n >= 5 {
+			n -= 5
+			buf[j] = crockford[(bits>>uint(n))&31]
+			j++
+		}
+	}
+	return string(buf[:])
+}
+
+func upper(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
+
+// IDSet is a thread safe set of 16 byte identifiers. The IDs are stored
+// unboxed as map keys, which takes a fraction of the memory of a Set of UUID
+// strings.
+type IDSet struct {
+	l sync.RWMutex
+	m map[ID]struct{}
+}
+
+// NewIDSet creates a new IDSet populated with ids.
+func NewIDSet(ids ...ID) *IDSet {
+	s := &IDSet{m: make(map[ID]struct{}, len(ids))}
+	s.Add(ids...)
+	return s
+}
+
+// Add includes the specified ids in the set.
+func (s *IDSet) Add(ids ...ID) {
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	for _, id := range ids {
+		s.m[id] = struct{}{}
+	}
+}
+
+// AddUUIDs parses and includes the specified UUIDs in the set. Nothing is
+// added if one of them is invalid.
+func (s *IDSet) AddUUIDs(uuids ...string) error {
+	ids := make([]ID, len(uuids))
+	for i, u := range uuids {
+		id, err := ParseUUID(u)
+		if err != nil {
+			return err
+		}
+		ids[i] = id
+	}
+	s.Add(ids...)
+	return nil
+}
+
+// Remove deletes the specified ids from the set.
+func (s *IDSet) Remove(ids ...ID) {
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	for _, id := range ids {
+		delete(s.m, id)
+	}
+}
+
+// Has looks for the existence of ids passed. It returns false if nothing is
+// passed. For multiple ids it returns true only if all of them exist.
+func (s *IDSet) Has(ids ...ID) bool {
+	if len(ids) == 0 {
+		return false
+	}
+
+	s.l.RLock()
+	defer s.l.RUnlock()
+
+	for _, id := range ids {
+		if _, ok := s.m[id]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Size returns the number of ids in the set.
+func (s *IDSet) Size() int {
+	s.l.RLock()
+	defer s.l.RUnlock()
+	return len(s.m)
+}
+
+// List returns all ids of the set in ascending byte order.
+func (s *IDSet) List() []ID {
+	s.l.RLock()
+	list := make([]ID, 0, len(s.m))
+	for id := range s.m {
+		list = append(list, id)
+	}
+	s.l.RUnlock()
+
+	sort.Slice(list, func(i, j int) bool {
+		return bytes.Compare(list[i][:], list[j][:]) < 0
+	})
+	return list
+}
+
+// MarshalBinary encodes the set compactly as the concatenation of its ids in
+// ascending order, 16 bytes per id.
+func (s *IDSet) MarshalBinary() ([]byte, error) {
+	list := s.List()
+	buf := make([]byte, 0, 16*len(list))
+	for _, id := range list {
+		buf = append(buf, id[:]...)
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary replaces the content of the set with the ids encoded by
+// MarshalBinary.
+func (s *IDSet) UnmarshalBinary(data []byte) error {
+	if len(data)%16 != 0 {
+		return fmt.Errorf("invalid IDSet encoding of %d bytes", len(data))
+	}
+
+	m := make(map[ID]struct{}, len(data)/16)
+	for i := 0; i < len(data); i += 16 {
+		m[ID(data[i:i+16])] = struct{}{}
+	}
+
+	s.l.Lock()
+	defer s.l.Unlock()
+	s.m = m
+	return nil
+}
@@ -0,0 +1,89 @@
+package goset
+
+import (
+	"testing"
+)
+
+func TestParseUUID(t *testing.T) {
+	const u = "123e4567-e89b-12d3-a456-426614174000"
+
+	id, err := ParseUUID(u)
+	if err != nil {
+		t.Fatal("ParseUUID: unexpected error", err)
+	}
+	if id.String() != u {
+		t.Errorf("ParseUUID: expected %s, got %s", u, id)
+	}
+
+	if other, _ := ParseUUID("123E4567E89B12D3A456426614174000"); other != id {
+		t.Error("ParseUUID: UUIDs without dashes should be accepted")
+	}
+
+	for _, invalid := range []string{"", "123e4567-e89b-12d3-a456-42661417400", "123e4567e-89b-12d3-a456-426614174000", "x23e4567-e89b-12d3-a456-426614174000"} {
+		if _, err := ParseUUID(invalid); err == nil {
+			t.Errorf("ParseUUID: %q should be invalid", invalid)
+		}
+	}
+}
+
+func TestParseULID(t *testing.T) {
+	const u = "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+
+	id, err := ParseULID(u)
+	if err != nil {
+		t.Fatal("ParseULID: unexpected error", err)
+	}
+	if id.ULID() != u {
+		t.Errorf("ParseULID: expected %s, got %s", u, id.ULID())
+	}
+
+	// the first 48 bits are the timestamp in milliseconds
+	if id[0] != 0x01 || id[1] != 0x56 {
+		t.Errorf("ParseULID: unexpected bytes %x", id)
+	}
+
+	if lower, _ := ParseULID("01arz3ndektsv4rrffq69g5fav"); lower != id {
+		t.Error("ParseULID: ULIDs should be case insensitive")
+	}
+
+	for _, invalid := range []string{"", "81ARZ3NDEKTSV4RRFFQ69G5FAV", "01ARZ3NDEKTSV4RRFFQ69G5FAU!", "01ARZ3NDEKTSV4RRFFQ69G5FAI"} {
+		if _, err := ParseULID(invalid); err == nil {
+			t.Errorf("ParseULID: %q should be invalid", invalid)
+		}
+	}
+}
+
+func TestIDSet(t *testing.T) {
+	s := NewIDSet()
+	if err := s.AddUUIDs("00000000-0000-0000-0000-000000000002", "00000000-0000-0000-0000-000000000001"); err != nil {
+		t.Fatal("IDSet: unexpected error", err)
+	}
+	if err := s.AddUUIDs("00000000-0000-0000-0000-000000000003", "invalid"); err == nil || s.Size() != 2 {
+		t.Error("IDSet: invalid UUIDs should add nothing")
+	}
+
+	one, _ := ParseUUID("00000000-0000-0000-0000-000000000001")
+	two, _ := ParseUUID("00000000-0000-0000-0000-000000000002")
+	if !s.Has(one, two) {
+		t.Error("IDSet: added ids are not available in the set")
+	}
+
+	data, _ := s.MarshalBinary()
+	if len(data) != 32 || data[15] != 1 || data[31] != 2 {
+		t.Errorf("IDSet: unexpected encoding %x", data)
+	}
+
+	u := NewIDSet()
+	if err := u.UnmarshalBinary(data); err != nil || !u.Has(one, two) || u.Size() != 2 {
+		t.Error("IDSet: decoding should restore the ids")
+	}
+
+	if err := u.UnmarshalBinary(data[:20]); err == nil {
+		t.Error("IDSet: truncated data should return an error")
+	}
+
+	s.Remove(one)
+	if s.Has(one) || s.Size() != 1 {
+		t.Error("IDSet: removed id should be gone")
+	}
+}
@@ -0,0 +1,46 @@
+package goset
+
+import "fmt"
+
+// ImportStats reports what happened to the items of a bulk import.
+type ImportStats struct {
+	New       int // items that were not in the set yet
+	Duplicate int // items already in the set, or passed more than once
+	Rejected  int // items that don't match the kind of the set
+}
+
+// Total returns the number of items passed to the import.
+func (st ImportStats) Total() int {
+	return st.New + st.Duplicate + st.Rejected
+}
+
+// String returns a human readable summary of the stats.
+func (st ImportStats) String() string {
+	return fmt.Sprintf("%d new, %d duplicate, %d rejected", st.New, st.Duplicate, st.Rejected)
+}
+
+// Import adds items to the set like Add, but instead of failing on the first
+// item of the wrong kind it skips it, and it reports how many of the items
+// were novel.
+func (s *Set) Import(items ...interface{}) ImportStats {
+	var st ImportStats
+	if len(items) == 0 {
+		return st
+	}
+
+	schedule("Add")
+	s.lock()
+	defer s.unlock()
+
+	for _, item := range items {
+		switch {
+		case s.typecheck(item) != nil:
+			st.Rejected++
+		case s.insert(item):
+			st.New++
+		default:
+			st.Duplicate++
+		}
+	}
+	return st
+}
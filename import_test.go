@@ -0,0 +1,26 @@
+package goset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSet_Import(t *testing.T) {
+	s := New(reflect.String, "a")
+
+	st := s.Import("a", "b", 1, "c", "b", nil)
+	expected := ImportStats{New: 2, Duplicate: 2, Rejected: 2}
+	if st != expected {
+		t.Errorf("Import: expected %v, got %v", expected, st)
+	}
+	if st.Total() != 6 {
+		t.Errorf("Import: expected a total of 6, got %d", st.Total())
+	}
+	if ok, _ := s.Has("a", "b", "c"); !ok || s.Size() != 3 {
+		t.Errorf("Import: expected [a b c], got %s", s)
+	}
+
+	if st := s.Import(); st != (ImportStats{}) {
+		t.Errorf("Import: expected empty stats, got %v", st)
+	}
+}
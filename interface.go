@@ -0,0 +1,30 @@
+package goset
+
+import "reflect"
+
+// Interface is the set of methods shared by the set implementations of this
+// package, so that they can be used interchangeably. The binary operations of
+// Set accept any Interface as their argument.
+type Interface interface {
+	Kind() reflect.Kind
+	Add(items ...interface{}) error
+	Remove(items ...interface{}) error
+	Has(items ...interface{}) (bool, error)
+	Size() int
+	List() []interface{}
+}
+
+var (
+	_ Interface = (*Set)(nil)
+	_ Interface = (*ChildSet)(nil)
+	_ Interface = (*CountingSet)(nil)
+)
+
+// asSet returns t if it's a *Set, or a new Set with the items of t
+// otherwise.
+func asSet(t Interface) *Set {
+	if s, ok := t.(*Set); ok {
+		return s
+	}
+	return New(t.Kind(), t.List()...)
+}
@@ -0,0 +1,34 @@
+package goset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInterface(t *testing.T) {
+	s := New(reflect.Int, 1, 2, 3)
+	c := New(reflect.Int, 2).Child()
+	c.Add(3)
+
+	if ok, _ := s.IsSubset(c); !ok {
+		t.Error("IsSubset: should accept any Interface")
+	}
+	if ok, _ := s.IsSuperset(c); ok {
+		t.Error("IsSuperset: should accept any Interface")
+	}
+	if ok, _ := s.IsDisjoint(c); ok {
+		t.Error("IsDisjoint: should accept any Interface")
+	}
+
+	u, err := s.SymmetricDifference(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := u.IsEqual(New(reflect.Int, 1)); !ok {
+		t.Errorf("SymmetricDifference: expected [1], got %s", u)
+	}
+
+	if err := s.Merge(New(reflect.String).Child()); err == nil {
+		t.Error("Merge: an Interface of another kind should return an error")
+	}
+}
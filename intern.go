@@ -0,0 +1,71 @@
+package goset
+
+import "sync"
+
+// InternPool holds a single copy of every string stored in the sets sharing
+// it, see WithInternPool. A string is kept as long as at least one set holds
+// it. An InternPool is safe for concurrent use; the zero value is ready to use.
+type InternPool struct {
+	mu      sync.Mutex
+	strings map[string]*interned
+}
+
+type interned struct {
+	s    string
+	refs int
+}
+
+// NewInternPool creates a new, empty InternPool.
+func NewInternPool() *InternPool {
+	return &InternPool{}
+}
+
+// Len returns the number of distinct strings held by the pool.
+func (p *InternPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.strings)
+}
+
+// acquire returns the canonical copy of item and takes a reference on it.
+// Items which are not strings are returned unchanged.
+func (p *InternPool) acquire(item interface{}) interface{} {
+	str, ok := item.(string)
+	if !ok {
+		return item
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.strings == nil {
+		p.strings = make(map[string]*interned)
+	}
+
+	e, ok := p.strings[str]
+	if !ok {
+		e = &interned{s: str}
+		p.strings[str] = e
+	}
+	e.refs++
+	return e.s
+}
+
+// release drops a reference taken by acquire. The string is forgotten by the
+// pool once the last reference is gone.
+func (p *InternPool) release(item interface{}) {
+	str, ok := item.(string)
+	if !ok {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if e, ok := p.strings[str]; ok {
+		e.refs--
+		if e.refs <= 0 {
+			delete(p.strings, str)
+		}
+	}
+}
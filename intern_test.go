@@ -0,0 +1,60 @@
+package goset
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func TestInternPool(t *testing.T) {
+	pool := NewInternPool()
+
+	a := NewWithOptions(reflect.String, WithInternPool(pool))
+	b := NewWithOptions(reflect.String, WithInternPool(pool))
+
+	// build equal strings with distinct backing arrays
+	a.Add(strings.Repeat("x", 8), "y")
+	b.Add(strings.Repeat("x", 8))
+
+	if pool.Len() != 2 {
+		t.Fatalf("InternPool: expected two strings in the pool, got %d", pool.Len())
+	}
+
+	sa, sb := a.StringSlice(), b.StringSlice()
+	var xa string
+	for _, str := range sa {
+		if str != "y" {
+			xa = str
+		}
+	}
+	if unsafe.StringData(xa) != unsafe.StringData(sb[0]) {
+		t.Error("InternPool: equal strings of both sets should share memory")
+	}
+
+	a.Remove("y")
+	if pool.Len() != 1 {
+		t.Error("InternPool: a string held by no set should be released")
+	}
+
+	a.Clear()
+	if pool.Len() != 1 {
+		t.Error("InternPool: a string still held by another set should be kept")
+	}
+
+	b.Remove(strings.Repeat("x", 8))
+	if pool.Len() != 0 {
+		t.Error("InternPool: the pool should be empty once no set holds a string")
+	}
+}
+
+func TestInternPool_duplicates(t *testing.T) {
+	pool := NewInternPool()
+	s := NewWithOptions(reflect.String, WithInternPool(pool), WithItems("a", "a"))
+	s.Add("a")
+	s.Remove("a")
+
+	if pool.Len() != 0 {
+		t.Error("InternPool: adding an existing item should not take another reference")
+	}
+}
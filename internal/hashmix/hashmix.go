@@ -0,0 +1,15 @@
+// Package hashmix holds the hash finalizer shared by goset and its
+// subpackages.
+package hashmix
+
+// Mix is the splitmix64 finalizer. It spreads every bit of x over all bits of
+// the result, which FNV alone does poorly for inputs differing only in their
+// last bytes.
+func Mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
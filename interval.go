@@ -0,0 +1,159 @@
+package goset
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Interval is the half-open range [Start, End) of values.
+type Interval[T cmp.Ordered] struct {
+	Start, End T
+}
+
+// Contains reports whether x is in the interval.
+func (iv Interval[T]) Contains(x T) bool {
+	return iv.Start <= x && x < iv.End
+}
+
+// String returns the interval in the [Start, End) notation.
+func (iv Interval[T]) String() string {
+	return fmt.Sprintf("[%v, %v)", iv.Start, iv.End)
+}
+
+// IntervalSet is a set of values stored as ranges rather than as individual
+// items, such as ranges of IDs or IP addresses. Overlapping and adjacent
+// intervals are merged automatically, so the set always holds the fewest
+// disjoint intervals covering its values. It's safe for concurrent use.
+type IntervalSet[T cmp.Ordered] struct {
+	l         sync.RWMutex
+	intervals []Interval[T] // sorted, disjoint and not adjacent
+}
+
+// NewIntervalSet creates a new IntervalSet holding the given intervals.
+func NewIntervalSet[T cmp.Ordered](intervals ...Interval[T]) *IntervalSet[T] {
+	s := &IntervalSet[T]{}
+	for _, iv := range intervals {
+		s.Add(iv.Start, iv.End)
+	}
+	return s
+}
+
+// Add includes the values of [start, end) in the set. An empty interval,
+// where end is not after start, is ignored.
+func (s *IntervalSet[T]) Add(start, end T) {
+	if end <= start {
+		return
+	}
+
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	// the intervals overlapping or adjacent to [start, end) are in [i, j)
+	i, _ := slices.BinarySearchFunc(s.intervals, start, func(iv Interval[T], x T) int {
+		return cmp.Compare(iv.End, x)
+	})
+	j := i
+	for j < len(s.intervals) && s.intervals[j].Start <= end {
+		start = min(start, s.intervals[j].Start)
+		end = max(end, s.intervals[j].End)
+		j++
+	}
+	s.intervals = slices.Replace(s.intervals, i, j, Interval[T]{start, end})
+}
+
+// Remove excludes the values of [start, end) from the set, splitting the
+// intervals that contain them if needed.
+func (s *IntervalSet[T]) Remove(start, end T) {
+	if end <= start {
+		return
+	}
+
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	res := make([]Interval[T], 0, len(s.intervals)+1)
+	for _, iv := range s.intervals {
+		if iv.End <= start || end <= iv.Start {
+			res = append(res, iv)
+			continue
+		}
+		if iv.Start < start {
+			res = append(res, Interval[T]{iv.Start, start})
+		}
+		if end < iv.End {
+			res = append(res, Interval[T]{end, iv.End})
+		}
+	}
+	s.intervals = res
+}
+
+// Contains reports whether x is in one of the intervals of the set.
+func (s *IntervalSet[T]) Contains(x T) bool {
+	s.l.RLock()
+	defer s.l.RUnlock()
+
+	i, _ := slices.BinarySearchFunc(s.intervals, x, func(iv Interval[T], x T) int {
+		if iv.End <= x {
+			return -1
+		}
+		return 1
+	})
+	return i < len(s.intervals) && s.intervals[i].Contains(x)
+}
+
+// Intervals returns the disjoint intervals of the set in ascending order.
+func (s *IntervalSet[T]) Intervals() []Interval[T] {
+	s.l.RLock()
+	defer s.l.RUnlock()
+
+	return slices.Clone(s.intervals)
+}
+
+// IsEmpty reports whether the set holds no values.
+func (s *IntervalSet[T]) IsEmpty() bool {
+	s.l.RLock()
+	defer s.l.RUnlock()
+
+	return len(s.intervals) == 0
+}
+
+// Union returns a new set with the values of s and t combined.
+func (s *IntervalSet[T]) Union(t *IntervalSet[T]) *IntervalSet[T] {
+	u := NewIntervalSet(s.Intervals()...)
+	for _, iv := range t.Intervals() {
+		u.Add(iv.Start, iv.End)
+	}
+	return u
+}
+
+// Intersection returns a new set with the values that are in both s and t.
+func (s *IntervalSet[T]) Intersection(t *IntervalSet[T]) *IntervalSet[T] {
+	a, b := s.Intervals(), t.Intervals()
+
+	u := &IntervalSet[T]{}
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		start, end := max(a[i].Start, b[j].Start), min(a[i].End, b[j].End)
+		if start < end {
+			u.intervals = append(u.intervals, Interval[T]{start, end})
+		}
+		if a[i].End < b[j].End {
+			i++
+		} else {
+			j++
+		}
+	}
+	return u
+}
+
+// String returns the intervals of the set, such as "[[10, 20) [35, 40)]".
+func (s *IntervalSet[T]) String() string {
+	intervals := s.Intervals()
+	t := make([]string, len(intervals))
+	for i, iv := range intervals {
+		t[i] = iv.String()
+	}
+	return fmt.Sprintf("[%s]", strings.Join(t, " "))
+}
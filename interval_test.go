@@ -0,0 +1,69 @@
+package goset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIntervalSet_Add(t *testing.T) {
+	s := NewIntervalSet[int]()
+	s.Add(10, 20)
+	s.Add(35, 40)
+	s.Add(0, 5)
+	s.Add(18, 25)
+	s.Add(25, 30)
+	s.Add(7, 7)
+
+	expected := []Interval[int]{{0, 5}, {10, 30}, {35, 40}}
+	if got := s.Intervals(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Add: expected %v, got %v", expected, got)
+	}
+
+	s.Add(3, 36)
+	if got := s.String(); got != "[[0, 40)]" {
+		t.Errorf("Add: expected [[0, 40)], got %s", got)
+	}
+}
+
+func TestIntervalSet_Remove(t *testing.T) {
+	s := NewIntervalSet(Interval[int]{0, 10}, Interval[int]{20, 30})
+	s.Remove(5, 25)
+
+	expected := []Interval[int]{{0, 5}, {25, 30}}
+	if got := s.Intervals(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Remove: expected %v, got %v", expected, got)
+	}
+
+	s.Remove(1, 2)
+	if got := s.String(); got != "[[0, 1) [2, 5) [25, 30)]" {
+		t.Errorf("Remove: expected the interval to be split, got %s", got)
+	}
+}
+
+func TestIntervalSet_Contains(t *testing.T) {
+	s := NewIntervalSet(Interval[int]{10, 20}, Interval[int]{35, 40})
+
+	for x, expected := range map[int]bool{9: false, 10: true, 19: true, 20: false, 35: true, 40: false} {
+		if s.Contains(x) != expected {
+			t.Errorf("Contains(%d): expected %v", x, expected)
+		}
+	}
+	if NewIntervalSet[int]().Contains(0) {
+		t.Error("Contains: an empty set should contain nothing")
+	}
+}
+
+func TestIntervalSet_UnionIntersection(t *testing.T) {
+	a := NewIntervalSet(Interval[float64]{0, 10}, Interval[float64]{20, 30})
+	b := NewIntervalSet(Interval[float64]{5, 25})
+
+	if got := a.Union(b).String(); got != "[[0, 30)]" {
+		t.Errorf("Union: expected [[0, 30)], got %s", got)
+	}
+	if got := a.Intersection(b).String(); got != "[[5, 10) [20, 25)]" {
+		t.Errorf("Intersection: expected [[5, 10) [20, 25)], got %s", got)
+	}
+	if !a.Intersection(NewIntervalSet(Interval[float64]{10, 20})).IsEmpty() {
+		t.Error("Intersection: adjacent intervals should not intersect")
+	}
+}
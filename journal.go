@@ -0,0 +1,127 @@
+package goset
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// journal records the changes of a set created with WithJournal. Every change
+// gets a sequence number greater than all before it, which isn't reused once
+// the change is undone. A checkpoint is the sequence number of the last change
+// recorded when it was taken, or base if there was none, so it stays valid as
+// long as that change isn't undone.
+type journal struct {
+	entries []journalEntry
+	seq     int // sequence number of the last change recorded
+	base    int // checkpoint of a journal without changes
+}
+
+type journalEntry struct {
+	item  interface{}
+	added bool // false if item was removed
+	seq   int
+}
+
+func (j *journal) record(item interface{}, added bool) {
+	j.seq++
+	j.entries = append(j.entries, journalEntry{item: item, added: added, seq: j.seq})
+}
+
+// checkpoint returns the number of changes recorded at the given checkpoint,
+// and false if a change recorded before it was undone or forgotten since.
+func (j *journal) checkpoint(cp int) (int, bool) {
+	if cp == j.base {
+		return 0, true
+	}
+	i := sort.Search(len(j.entries), func(i int) bool { return j.entries[i].seq >= cp })
+	if i == len(j.entries) || j.entries[i].seq != cp {
+		return 0, false
+	}
+	return i + 1, true
+}
+
+// errNoJournal is returned by the journal methods of sets created without
+// WithJournal.
+var errNoJournal = errors.New("set has no journal, see WithJournal")
+
+// Checkpoint returns a checkpoint of the current state of s, to be passed to
+// RollbackTo. It returns an error if s was not created with WithJournal.
+func (s *Set) Checkpoint() (int, error) {
+	s.rlock()
+	defer s.l.RUnlock()
+
+	j := s.journal
+	if j == nil {
+		return 0, errNoJournal
+	}
+	if len(j.entries) == 0 {
+		return j.base, nil
+	}
+	return j.entries[len(j.entries)-1].seq, nil
+}
+
+// Undo reverts the last n changes of s, each change being an item added or
+// removed. It returns an error if s was not created with WithJournal, or if
+// fewer than n changes were recorded, in which case nothing is reverted.
+func (s *Set) Undo(n int) error {
+	s.lock()
+	defer s.unlock()
+
+	if s.journal == nil {
+		return errNoJournal
+	}
+	if n < 0 || n > len(s.journal.entries) {
+		return fmt.Errorf("cannot undo %d changes, %d are recorded", n, len(s.journal.entries))
+	}
+	s.revert(len(s.journal.entries) - n)
+	return s.flushWAL()
+}
+
+// RollbackTo reverts all changes of s made since the given checkpoint was
+// taken. It returns an error if s was not created with WithJournal, or if the
+// checkpoint is not valid anymore: if a change made before it was taken was
+// undone since, or the journal was forgotten.
+func (s *Set) RollbackTo(checkpoint int) error {
+	s.lock()
+	defer s.unlock()
+
+	if s.journal == nil {
+		return errNoJournal
+	}
+	n, ok := s.journal.checkpoint(checkpoint)
+	if !ok {
+		return fmt.Errorf("checkpoint %d is not valid anymore", checkpoint)
+	}
+	s.revert(n)
+	return s.flushWAL()
+}
+
+// ForgetJournal discards the changes recorded so far, which can't be
+// reverted anymore. Checkpoints taken before are invalidated.
+func (s *Set) ForgetJournal() {
+	s.lock()
+	defer s.unlock()
+
+	if j := s.journal; j != nil {
+		j.entries = nil
+		j.seq++
+		j.base = j.seq
+	}
+}
+
+// revert reverts the changes recorded after the first n ones and removes them
+// from the journal. The write lock must be held.
+func (s *Set) revert(n int) {
+	j := s.journal
+	s.journal = nil // reverting is not recorded
+	for i := len(j.entries) - 1; i >= n; i-- {
+		if e := j.entries[i]; e.added {
+			s.delete(e.item)
+		} else {
+			s.insert(e.item)
+		}
+	}
+	j.entries = j.entries[:n]
+	s.journal = j
+}
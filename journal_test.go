@@ -0,0 +1,92 @@
+package goset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSet_Undo(t *testing.T) {
+	s := NewWithOptions(reflect.String, WithJournal(), WithItems("a"))
+	s.Add("b", "c")
+	s.Remove("a")
+
+	if err := s.Undo(1); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := s.Has("a", "b", "c"); !ok {
+		t.Errorf("Undo: expected [a b c], got %s", s)
+	}
+
+	if err := s.Undo(2); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := s.Has("a"); !ok || s.Size() != 1 {
+		t.Errorf("Undo: expected [a], got %s", s)
+	}
+
+	if err := s.Undo(1); err == nil {
+		t.Error("Undo: the initial items should not be undone")
+	}
+}
+
+func TestSet_RollbackTo(t *testing.T) {
+	s := NewWithOptions(reflect.Int, WithJournal(), WithItems(1, 2))
+
+	cp, err := s.Checkpoint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Add(3)
+	s.Clear()
+	s.Add(4, 1)
+
+	if err := s.RollbackTo(cp); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := s.IsEqual(New(reflect.Int, 1, 2)); !ok {
+		t.Errorf("RollbackTo: expected [1 2], got %s", s)
+	}
+
+	s.Add(5)
+	s.ForgetJournal()
+	if err := s.RollbackTo(cp + 1); err == nil {
+		t.Error("RollbackTo: checkpoints should be invalidated by ForgetJournal")
+	}
+	if ok, _ := s.Has(5); !ok {
+		t.Error("ForgetJournal: the set should not be modified")
+	}
+
+	if _, err := New(reflect.Int).Checkpoint(); err == nil {
+		t.Error("Checkpoint: a set without journal should return an error")
+	}
+	if err := New(reflect.Int).Undo(0); err == nil {
+		t.Error("Undo: a set without journal should return an error")
+	}
+}
+
+func TestSet_RollbackTo_undone(t *testing.T) {
+	s := NewWithOptions(reflect.String, WithJournal())
+	s.Add("a", "b", "c", "d", "e")
+
+	cp, _ := s.Checkpoint()
+	s.Undo(3)
+	s.Add("x", "y", "z", "w")
+
+	if err := s.RollbackTo(cp); err == nil {
+		t.Errorf("RollbackTo: a checkpoint whose changes were undone should be rejected, got %s", s)
+	}
+	if s.Size() != 6 {
+		t.Errorf("RollbackTo: the set should not be modified, got %s", s)
+	}
+
+	cp, _ = s.Checkpoint()
+	s.Add("v")
+	s.Undo(1)
+	s.Remove("x")
+	if err := s.RollbackTo(cp); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := s.Has("a", "b", "x", "y", "z", "w"); !ok || s.Size() != 6 {
+		t.Errorf("RollbackTo: expected [a b w x y z], got %s", s)
+	}
+}
@@ -0,0 +1,35 @@
+package goset
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// MarshalJSON implements json.Marshaler: s is encoded as a JSON array of its
+// items. The items are in sorted order if s was created with
+// WithSortedOutput, in no particular order otherwise.
+func (s *Set) MarshalJSON() ([]byte, error) {
+	list := s.List()
+	if s.sortedOutput {
+		list = s.sortedList()
+	}
+	return json.Marshal(list)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It replaces the items of s with
+// the ones of a JSON array, converted to the kind of s; the kind of a zero Set
+// is inferred from them.
+func (s *Set) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var values []interface{}
+	if err := dec.Decode(&values); err != nil {
+		return fmt.Errorf("goset: decoding JSON: %s", err)
+	}
+	if err := s.replaceWith(values); err != nil {
+		return fmt.Errorf("goset: decoding JSON: %s", err)
+	}
+	return nil
+}
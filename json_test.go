@@ -0,0 +1,40 @@
+package goset
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestSet_MarshalJSON(t *testing.T) {
+	s := NewWithOptions(reflect.Int, WithSortedOutput(), WithItems(3, 1, 2))
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "[1,2,3]" {
+		t.Errorf("MarshalJSON: expected [1,2,3], got %s", data)
+	}
+	if s.String() != "[1, 2, 3]" {
+		t.Errorf("String: expected [1, 2, 3], got %s", s)
+	}
+
+	u := New(reflect.String, "b", "a")
+	if u.SortedString() != "[a, b]" {
+		t.Errorf("SortedString: expected [a, b], got %s", u.SortedString())
+	}
+
+	var v struct{ Tags *Set }
+	if err := json.Unmarshal([]byte(`{"Tags":["x","y","x"]}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := v.Tags.Has("x", "y"); !ok || v.Tags.Size() != 2 {
+		t.Errorf("UnmarshalJSON: expected [x y], got %s", v.Tags)
+	}
+
+	w := New(reflect.Int)
+	if err := json.Unmarshal([]byte(`["a"]`), w); err == nil {
+		t.Error("UnmarshalJSON: values that can't be converted should return an error")
+	}
+}
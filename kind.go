@@ -0,0 +1,31 @@
+package goset
+
+import "reflect"
+
+// SupportedKinds returns the kinds a Set can be created for, in ascending
+// order. Items of these kinds can be used as map keys and are accepted by Add,
+// Remove and Has. Array and Struct kinds are supported as long as the actual
+// value is comparable; an array or struct holding a slice, map or func (for
+// example through an interface field) is rejected with an error.
+//
+// Func, Map and Slice values are not hashable and Interface and Invalid are
+// never the kind of a concrete value, so every operation that takes items on a
+// set of one of these kinds returns an error.
+func SupportedKinds() []reflect.Kind {
+	kinds := make([]reflect.Kind, 0)
+	for k := reflect.Invalid; k <= reflect.UnsafePointer; k++ {
+		if IsSupportedKind(k) {
+			kinds = append(kinds, k)
+		}
+	}
+	return kinds
+}
+
+// IsSupportedKind reports whether a Set of the given kind can hold items.
+func IsSupportedKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Invalid, reflect.Func, reflect.Interface, reflect.Map, reflect.Slice:
+		return false
+	}
+	return kind <= reflect.UnsafePointer
+}
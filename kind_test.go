@@ -0,0 +1,135 @@
+package goset
+
+import (
+	"reflect"
+	"testing"
+	"unsafe"
+)
+
+// kindSamples holds a value of every kind that a concrete value can have.
+var kindSamples = map[reflect.Kind]interface{}{
+	reflect.Bool:          true,
+	reflect.Int:           1,
+	reflect.Int8:          int8(1),
+	reflect.Int16:         int16(1),
+	reflect.Int32:         int32(1),
+	reflect.Int64:         int64(1),
+	reflect.Uint:          uint(1),
+	reflect.Uint8:         uint8(1),
+	reflect.Uint16:        uint16(1),
+	reflect.Uint32:        uint32(1),
+	reflect.Uint64:        uint64(1),
+	reflect.Uintptr:       uintptr(1),
+	reflect.Float32:       float32(1),
+	reflect.Float64:       float64(1),
+	reflect.Complex64:     complex64(1),
+	reflect.Complex128:    complex128(1),
+	reflect.Array:         [2]int{1, 2},
+	reflect.Chan:          make(chan int),
+	reflect.Func:          func() {},
+	reflect.Map:           map[int]int{},
+	reflect.Pointer:       new(int),
+	reflect.Slice:         []int{1},
+	reflect.String:        "1",
+	reflect.Struct:        struct{ a int }{1},
+	reflect.UnsafePointer: unsafe.Pointer(new(int)),
+}
+
+func TestSupportedKinds(t *testing.T) {
+	supported := New(reflect.Uint, func() []interface{} {
+		items := make([]interface{}, 0)
+		for _, k := range SupportedKinds() {
+			items = append(items, uint(k))
+		}
+		return items
+	}()...)
+
+	for k := reflect.Invalid; k <= reflect.UnsafePointer+1; k++ {
+		if ok, _ := supported.Has(uint(k)); ok != IsSupportedKind(k) {
+			t.Errorf("SupportedKinds: kind '%s' is listed %v, but IsSupportedKind returns %v", k, ok, !ok)
+		}
+
+		v, ok := kindSamples[k]
+		if !ok {
+			continue
+		}
+
+		s := New(k)
+		err := s.Add(v)
+		if IsSupportedKind(k) {
+			if err != nil {
+				t.Errorf("SupportedKinds: adding a value of kind '%s' failed: %s", k, err)
+			}
+			if ok, _ := s.Has(v); !ok {
+				t.Errorf("SupportedKinds: added value of kind '%s' is not available in the set", k)
+			}
+		} else if err == nil {
+			t.Errorf("SupportedKinds: adding a value of unsupported kind '%s' should return an error", k)
+		}
+	}
+
+	if IsSupportedKind(reflect.Interface) || IsSupportedKind(reflect.Invalid) {
+		t.Error("SupportedKinds: Interface and Invalid are never the kind of a value")
+	}
+}
+
+func TestSet_Add_unhashable(t *testing.T) {
+	s := New(reflect.Struct)
+	if err := s.Add(struct{ v interface{} }{[]int{1}}); err == nil {
+		t.Error("Add: adding a struct holding a slice should return an error")
+	}
+
+	a := New(reflect.Array)
+	if err := a.Add([1]interface{}{map[int]int{}}); err == nil {
+		t.Error("Add: adding an array holding a map should return an error")
+	}
+
+	if err := New(reflect.Int).Add(nil); err == nil {
+		t.Error("Add: adding nil should return an error")
+	}
+
+	if s.Size() != 0 || a.Size() != 0 {
+		t.Error("Add: rejected items should not be in the set")
+	}
+}
+
+func FuzzSet_Add(f *testing.F) {
+	f.Add(uint8(reflect.String), "goset", int64(42), false)
+	f.Add(uint8(reflect.Struct), "", int64(-1), true)
+	f.Add(uint8(reflect.Slice), "x", int64(0), true)
+
+	f.Fuzz(func(t *testing.T, kind uint8, str string, n int64, wrap bool) {
+		k := reflect.Kind(kind % uint8(reflect.UnsafePointer+2))
+
+		var item interface{}
+		switch n % 6 {
+		case 0:
+			item = str
+		case 1:
+			item = n
+		case 2:
+			item = []byte(str)
+		case 3:
+			item = struct{ v interface{} }{str}
+		case 4:
+			item = [1]interface{}{[]int{int(n)}}
+		default:
+			item = kindSamples[k]
+		}
+		if wrap {
+			item = struct{ v interface{} }{item}
+		}
+
+		s := New(k)
+		err := s.Add(item) // must never panic
+
+		valid := item != nil && IsSupportedKind(k) &&
+			reflect.TypeOf(item).Kind() == k && reflect.ValueOf(item).Comparable()
+		if valid != (err == nil) {
+			t.Fatalf("Add: kind '%s' item %#v returned error %v", k, item, err)
+		}
+		if ok, _ := s.Has(item); ok != valid {
+			t.Fatalf("Has: kind '%s' item %#v reported %v", k, item, ok)
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package goset
+
+import (
+	"reflect"
+	"sync"
+)
+
+type lazy struct {
+	once sync.Once
+	fill func(add func(items ...interface{}) error) error
+	err  error
+}
+
+// NewLazy creates a new Set of the given kind that is populated by fill the
+// first time it's accessed, so that an expensive bootstrap, such as a
+// database query, only happens if the set is actually consulted. fill is
+// called once, even if the set is accessed concurrently, and adds items with
+// the add function it's passed. It must not access the set otherwise.
+//
+// If fill returns an error, the set keeps the items added so far. The error
+// is returned by Populate.
+func NewLazy(kind reflect.Kind, fill func(add func(items ...interface{}) error) error) *Set {
+	s := New(kind)
+	s.lazy = &lazy{fill: fill}
+	return s
+}
+
+// Populate fills a set created with NewLazy if it wasn't accessed yet, and
+// returns the error of the fill function. It returns nil for other sets.
+func (s *Set) Populate() error {
+	if s.lazy == nil {
+		return nil
+	}
+	s.populate()
+	return s.lazy.err
+}
+
+func (s *Set) populate() {
+	s.lazy.once.Do(func() {
+		s.lazy.err = s.lazy.fill(func(items ...interface{}) error {
+			if err := s.typecheck(items...); err != nil {
+				return err
+			}
+
+			s.l.Lock()
+			defer s.l.Unlock()
+
+			for _, item := range items {
+				s.insert(item)
+			}
+			// populating the set isn't a change observers are told about
+			s.added = s.added[:0]
+			return nil
+		})
+	})
+}
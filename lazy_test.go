@@ -0,0 +1,60 @@
+package goset
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestNewLazy(t *testing.T) {
+	calls := 0
+	s := NewLazy(reflect.String, func(add func(items ...interface{}) error) error {
+		calls++
+		return add("a", "b")
+	})
+	if calls != 0 {
+		t.Fatal("NewLazy: the set should not be populated before it's accessed")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if ok, _ := s.Has("a", "b"); !ok {
+				t.Error("NewLazy: the items added by fill should be visible")
+			}
+		}()
+	}
+	wg.Wait()
+
+	s.Add("c")
+	if calls != 1 || s.Size() != 3 {
+		t.Errorf("NewLazy: fill should be called once, got %d calls and %s", calls, s)
+	}
+	if err := s.Populate(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSet_Populate(t *testing.T) {
+	failed := errors.New("query failed")
+	s := NewLazy(reflect.Int, func(add func(items ...interface{}) error) error {
+		if err := add("x"); err == nil {
+			t.Error("NewLazy: items of another kind should return an error")
+		}
+		add(1)
+		return failed
+	})
+	if err := s.Populate(); err != failed {
+		t.Errorf("Populate: expected the error of fill, got %v", err)
+	}
+	if ok, _ := s.Has(1); !ok {
+		t.Error("Populate: items added before the error should be kept")
+	}
+
+	if err := New(reflect.Int).Populate(); err != nil {
+		t.Error("Populate: should return nil for sets not created by NewLazy")
+	}
+}
@@ -0,0 +1,119 @@
+package goset
+
+import (
+	"sync"
+	"time"
+)
+
+// LockStrategy is the way a set synchronizes concurrent access, see
+// WithLocking.
+type LockStrategy int
+
+const (
+	// LockRW uses a sync.RWMutex: readers run concurrently with each other
+	// and exclusively with writers. It suits read-heavy workloads.
+	LockRW LockStrategy = iota
+
+	// LockMutex uses a sync.Mutex for readers and writers alike. It's
+	// cheaper than LockRW for write-heavy workloads or short critical
+	// sections.
+	LockMutex
+
+	// LockNone doesn't synchronize at all, for sets confined to a single
+	// goroutine. Such a set must not be used concurrently, including by the
+	// goroutines of Watch and ForEachMember.
+	LockNone
+)
+
+// String returns the name of the strategy.
+func (ls LockStrategy) String() string {
+	switch ls {
+	case LockRW:
+		return "rw"
+	case LockMutex:
+		return "mutex"
+	case LockNone:
+		return "none"
+	}
+	return "unknown"
+}
+
+// setLock is the lock of a set, implementing its LockStrategy. The zero value
+// uses LockRW.
+type setLock struct {
+	strategy LockStrategy
+	rw       sync.RWMutex
+	mu       sync.Mutex
+}
+
+func (l *setLock) Lock() {
+	switch l.strategy {
+	case LockMutex:
+		l.mu.Lock()
+	case LockNone:
+	default:
+		l.rw.Lock()
+	}
+}
+
+func (l *setLock) Unlock() {
+	switch l.strategy {
+	case LockMutex:
+		l.mu.Unlock()
+	case LockNone:
+	default:
+		l.rw.Unlock()
+	}
+}
+
+func (l *setLock) RLock() {
+	switch l.strategy {
+	case LockMutex:
+		l.mu.Lock()
+	case LockNone:
+	default:
+		l.rw.RLock()
+	}
+}
+
+func (l *setLock) RUnlock() {
+	switch l.strategy {
+	case LockMutex:
+		l.mu.Unlock()
+	case LockNone:
+	default:
+		l.rw.RUnlock()
+	}
+}
+
+// lock acquires the write lock of s, recording the time spent waiting for it
+// if the set is instrumented. Release it with s.unlock.
+func (s *Set) lock() {
+	if s.lazy != nil {
+		s.populate()
+	}
+	if s.metrics == nil {
+		s.l.Lock()
+		return
+	}
+
+	start := time.Now()
+	s.l.Lock()
+	s.metrics.waited(time.Since(start))
+}
+
+// rlock acquires the read lock of s, recording the time spent waiting for it
+// if the set is instrumented. Release it with s.l.RUnlock.
+func (s *Set) rlock() {
+	if s.lazy != nil {
+		s.populate()
+	}
+	if s.metrics == nil {
+		s.l.RLock()
+		return
+	}
+
+	start := time.Now()
+	s.l.RLock()
+	s.metrics.waited(time.Since(start))
+}
@@ -0,0 +1,37 @@
+package goset
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestWithLocking(t *testing.T) {
+	for _, strategy := range []LockStrategy{LockRW, LockMutex} {
+		s := NewWithOptions(reflect.Int, WithLocking(strategy))
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					s.Add(i*100 + j)
+					s.Has(j)
+					s.Size()
+				}
+			}()
+		}
+		wg.Wait()
+
+		if s.Size() != 1000 {
+			t.Errorf("WithLocking(%s): expected 1000 items, got %d", strategy, s.Size())
+		}
+	}
+
+	s := NewWithOptions(reflect.Int, WithLocking(LockNone), WithItems(1, 2))
+	s.Remove(1)
+	if ok, _ := s.Has(2); !ok || s.Size() != 1 {
+		t.Errorf("WithLocking(none): expected [2], got %s", s)
+	}
+}
@@ -0,0 +1,123 @@
+package goset
+
+import (
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// metrics holds the counters of an instrumented set.
+type metrics struct {
+	adds     atomic.Int64
+	removes  atomic.Int64
+	lookups  atomic.Int64
+	hits     atomic.Int64
+	locks    atomic.Int64
+	lockWait atomic.Int64 // nanoseconds
+}
+
+func (m *metrics) lookup(hit bool) {
+	m.lookups.Add(1)
+	if hit {
+		m.hits.Add(1)
+	}
+}
+
+func (m *metrics) waited(d time.Duration) {
+	m.locks.Add(1)
+	m.lockWait.Add(int64(d))
+}
+
+// Metrics are the counters of a set created with the WithMetrics option, see
+// Set.Metrics. Counters are cumulative since the creation of the set, rates
+// are obtained by sampling them over time.
+type Metrics struct {
+	Size     int           // number of items in the set
+	Adds     int64         // items added
+	Removes  int64         // items removed
+	Lookups  int64         // items looked up, for example by Has
+	Hits     int64         // items looked up that were found
+	Locks    int64         // read and write locks acquired
+	LockWait time.Duration // total time spent waiting for locks
+}
+
+// Metrics returns the current metrics of s. Only Size is set unless s was
+// created with the WithMetrics option.
+func (s *Set) Metrics() Metrics {
+	m := Metrics{Size: s.Size()}
+	if s.metrics == nil {
+		return m
+	}
+
+	m.Adds = s.metrics.adds.Load()
+	m.Removes = s.metrics.removes.Load()
+	m.Lookups = s.metrics.lookups.Load()
+	m.Hits = s.metrics.hits.Load()
+	m.Locks = s.metrics.locks.Load()
+	m.LockWait = time.Duration(s.metrics.lockWait.Load())
+	return m
+}
+
+// Expvar returns an expvar.Var publishing the metrics of s as JSON:
+//
+//	expvar.Publish("sessions", sessions.Expvar())
+func (s *Set) Expvar() expvar.Var {
+	return expvar.Func(func() interface{} {
+		return s.Metrics()
+	})
+}
+
+// prometheusMetrics describes the metrics in the Prometheus text format.
+var prometheusMetrics = []struct {
+	name, typ, help string
+	value           func(m Metrics) float64
+}{
+	{"goset_size", "gauge", "Number of items in the set.", func(m Metrics) float64 { return float64(m.Size) }},
+	{"goset_adds_total", "counter", "Items added to the set.", func(m Metrics) float64 { return float64(m.Adds) }},
+	{"goset_removes_total", "counter", "Items removed from the set.", func(m Metrics) float64 { return float64(m.Removes) }},
+	{"goset_lookups_total", "counter", "Items looked up in the set.", func(m Metrics) float64 { return float64(m.Lookups) }},
+	{"goset_hits_total", "counter", "Items looked up in the set that were found.", func(m Metrics) float64 { return float64(m.Hits) }},
+	{"goset_locks_total", "counter", "Locks of the set acquired.", func(m Metrics) float64 { return float64(m.Locks) }},
+	{"goset_lock_wait_seconds_total", "counter", "Time spent waiting for the lock of the set.", func(m Metrics) float64 { return m.LockWait.Seconds() }},
+}
+
+// WritePrometheus writes the metrics of the given sets to w in the Prometheus
+// text exposition format. Every sample is labeled with the name of its set,
+// so sets should be given distinct names with the WithName option.
+func WritePrometheus(w io.Writer, sets ...*Set) error {
+	all := make([]Metrics, len(sets))
+	for i, s := range sets {
+		all[i] = s.Metrics()
+	}
+
+	for _, pm := range prometheusMetrics {
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", pm.name, pm.help, pm.name, pm.typ); err != nil {
+			return err
+		}
+		for i, s := range sets {
+			value := strconv.FormatFloat(pm.value(all[i]), 'g', -1, 64)
+			if _, err := fmt.Fprintf(w, "%s{set=\"%s\"} %s\n", pm.name, labelEscaper.Replace(s.Name()), value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// labelEscaper escapes label values for the Prometheus text exposition format,
+// which only knows about backslashes, double quotes and line feeds.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// PrometheusHandler returns an http.Handler serving the metrics of the given
+// sets for Prometheus to scrape, see WritePrometheus.
+func PrometheusHandler(sets ...*Set) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		WritePrometheus(w, sets...)
+	})
+}
@@ -0,0 +1,85 @@
+package goset
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSet_Metrics(t *testing.T) {
+	s := NewWithOptions(reflect.Int, WithMetrics(), WithItems(1, 2))
+
+	s.Add(2, 3)
+	s.Remove(1, 4)
+	s.Has(2, 3)
+	s.HasEach(1, 2)
+	s.Clear()
+
+	m := s.Metrics()
+	expected := Metrics{Size: 0, Adds: 3, Removes: 3, Lookups: 4, Hits: 3}
+	m.Locks, m.LockWait = 0, 0
+	if m != expected {
+		t.Errorf("Metrics: expected %+v, got %+v", expected, m)
+	}
+
+	if s.Metrics().Locks == 0 {
+		t.Error("Metrics: lock acquisitions should be counted")
+	}
+
+	if (New(reflect.Int, 1).Metrics() != Metrics{Size: 1}) {
+		t.Error("Metrics: a set without metrics should only report its size")
+	}
+}
+
+func TestSet_Expvar(t *testing.T) {
+	s := NewWithOptions(reflect.Int, WithMetrics(), WithItems(1, 2))
+
+	var m Metrics
+	if err := json.Unmarshal([]byte(s.Expvar().String()), &m); err != nil {
+		t.Fatal("Expvar: invalid JSON", err)
+	}
+
+	if m.Size != 2 || m.Adds != 2 {
+		t.Errorf("Expvar: unexpected metrics %+v", m)
+	}
+}
+
+func TestPrometheusHandler(t *testing.T) {
+	a := NewWithOptions(reflect.Int, WithName("a"), WithMetrics(), WithItems(1, 2))
+	b := NewWithOptions(reflect.Int, WithName("b"))
+
+	rec := httptest.NewRecorder()
+	PrometheusHandler(a, b).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	out := rec.Body.String()
+
+	for _, line := range []string{
+		"# TYPE goset_size gauge",
+		`goset_size{set="a"} 2`,
+		`goset_size{set="b"} 0`,
+		`goset_adds_total{set="a"} 2`,
+		"# TYPE goset_lock_wait_seconds_total counter",
+	} {
+		if !strings.Contains(out, line+"\n") {
+			t.Errorf("PrometheusHandler: output is missing %q:\n%s", line, out)
+		}
+	}
+
+	if strings.Count(out, "# HELP goset_size ") != 1 {
+		t.Error("PrometheusHandler: every metric should be described once")
+	}
+}
+
+func TestWritePrometheus_escaping(t *testing.T) {
+	s := NewWithOptions(reflect.Int, WithName("a\tb\"c\nd\\é"))
+
+	var buf bytes.Buffer
+	if err := WritePrometheus(&buf, s); err != nil {
+		t.Fatal(err)
+	}
+	if line := `goset_size{set="a` + "\t" + `b\"c\nd\\é"} 0`; !strings.Contains(buf.String(), line+"\n") {
+		t.Errorf("WritePrometheus: output is missing %q:\n%s", line, buf.String())
+	}
+}
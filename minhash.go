@@ -0,0 +1,58 @@
+package goset
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+
+	"github.com/fatih/goset/internal/hashmix"
+)
+
+// Signature is a MinHash signature of a set, see Set.MinHash.
+type Signature []uint64
+
+// MinHash returns a MinHash signature of s made of numHashes values. The
+// similarity of two sets can be estimated from their signatures with
+// EstimateJaccard in O(numHashes), the error of the estimate being about
+// 1/sqrt(numHashes). Items are hashed by their type and %v representation.
+// Signatures of sets of numbers, strings or bools are therefore stable across
+// processes and can be stored; those of items printed with their address,
+// such as pointers or structs holding pointers, are not.
+func (s *Set) MinHash(numHashes int) Signature {
+	sig := make(Signature, max(numHashes, 0))
+	for i := range sig {
+		sig[i] = math.MaxUint64
+	}
+
+	for _, item := range s.List() {
+		h := fnv.New64a()
+		fmt.Fprintf(h, "%T:%v", item, item)
+		x := h.Sum64()
+
+		for i := range sig {
+			if v := hashmix.Mix(x ^ hashmix.Mix(uint64(i)+1)); v < sig[i] {
+				sig[i] = v
+			}
+		}
+	}
+	return sig
+}
+
+// EstimateJaccard estimates the Jaccard index of two sets from their MinHash
+// signatures, which must have the same number of values.
+func EstimateJaccard(a, b Signature) (float64, error) {
+	if len(a) != len(b) {
+		return 0, fmt.Errorf("cannot compare signatures of different lengths; %d != %d", len(a), len(b))
+	}
+	if len(a) == 0 {
+		return 0, fmt.Errorf("cannot compare empty signatures")
+	}
+
+	equal := 0
+	for i := range a {
+		if a[i] == b[i] {
+			equal++
+		}
+	}
+	return float64(equal) / float64(len(a)), nil
+}
@@ -0,0 +1,36 @@
+package goset
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestSet_MinHash(t *testing.T) {
+	a, b := New(reflect.Int), New(reflect.Int)
+	for i := 0; i < 1000; i++ {
+		a.Add(i)
+		b.Add(i + 500)
+	}
+
+	sa, sb := a.MinHash(256), b.MinHash(256)
+	if len(sa) != 256 {
+		t.Fatalf("MinHash: expected 256 values, got %d", len(sa))
+	}
+
+	est, err := EstimateJaccard(sa, sb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exact, _ := a.Similarity(b)
+	if math.Abs(est-exact.Jaccard) > 0.1 {
+		t.Errorf("EstimateJaccard: expected about %.2f, got %.2f", exact.Jaccard, est)
+	}
+
+	if est, _ := EstimateJaccard(sa, a.Copy().MinHash(256)); est != 1 {
+		t.Errorf("EstimateJaccard: equal sets should have equal signatures, got %.2f", est)
+	}
+	if _, err := EstimateJaccard(sa, a.MinHash(128)); err == nil {
+		t.Error("EstimateJaccard: signatures of different lengths should return an error")
+	}
+}
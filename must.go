@@ -0,0 +1,72 @@
+package goset
+
+// The Must variants of the methods of Set panic instead of returning an error.
+// They're meant for callers that control all inputs, for which a kind mismatch
+// is a programming error.
+
+// MustAdd is like Add but panics if an item doesn't match the kind of s.
+func (s *Set) MustAdd(items ...interface{}) {
+	must(s.Add(items...))
+}
+
+// MustRemove is like Remove but panics if an item doesn't match the kind of
+// s.
+func (s *Set) MustRemove(items ...interface{}) {
+	must(s.Remove(items...))
+}
+
+// MustHas is like Has but panics if an item doesn't match the kind of s.
+func (s *Set) MustHas(items ...interface{}) bool {
+	ok, err := s.Has(items...)
+	must(err)
+	return ok
+}
+
+// MustUnion is like Union but panics if t is of another kind.
+func (s *Set) MustUnion(t Interface) *Set {
+	u, err := s.Union(t)
+	must(err)
+	return u
+}
+
+// MustIntersection is like Intersection but panics if t is of another kind.
+func (s *Set) MustIntersection(t Interface) *Set {
+	u, err := s.Intersection(t)
+	must(err)
+	return u
+}
+
+// MustDifference is like Difference but panics if t is of another kind.
+func (s *Set) MustDifference(t Interface) *Set {
+	u, err := s.Difference(t)
+	must(err)
+	return u
+}
+
+// MustSymmetricDifference is like SymmetricDifference but panics if t is of
+// another kind.
+func (s *Set) MustSymmetricDifference(t Interface) *Set {
+	u, err := s.SymmetricDifference(t)
+	must(err)
+	return u
+}
+
+// MustIsEqual is like IsEqual but panics if t is of another kind.
+func (s *Set) MustIsEqual(t Interface) bool {
+	ok, err := s.IsEqual(t)
+	must(err)
+	return ok
+}
+
+// MustIsSubset is like IsSubset but panics if t is of another kind.
+func (s *Set) MustIsSubset(t Interface) bool {
+	ok, err := s.IsSubset(t)
+	must(err)
+	return ok
+}
+
+func must(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,39 @@
+package goset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSet_Must(t *testing.T) {
+	s := New(reflect.Int, 1, 2)
+	s.MustAdd(3)
+	s.MustRemove(1)
+	if !s.MustHas(2, 3) || s.MustHas(1) {
+		t.Errorf("Must: expected [2 3], got %s", s)
+	}
+
+	u := s.MustUnion(New(reflect.Int, 4))
+	if !u.MustIsEqual(New(reflect.Int, 2, 3, 4)) {
+		t.Errorf("MustUnion: expected [2 3 4], got %s", u)
+	}
+	if !u.MustIsSubset(s) || s.MustIntersection(u).Size() != 2 || u.MustDifference(s).Size() != 1 {
+		t.Error("Must: unexpected result of a binary operation")
+	}
+	if s.MustSymmetricDifference(u).Size() != 1 {
+		t.Error("MustSymmetricDifference: expected [4]")
+	}
+
+	mustPanic := func(name string, fn func()) {
+		t.Helper()
+		defer func() {
+			if recover() == nil {
+				t.Errorf("%s: a kind mismatch should panic", name)
+			}
+		}()
+		fn()
+	}
+	mustPanic("MustAdd", func() { s.MustAdd("a") })
+	mustPanic("MustHas", func() { s.MustHas("a") })
+	mustPanic("MustUnion", func() { s.MustUnion(New(reflect.String)) })
+}
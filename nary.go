@@ -0,0 +1,151 @@
+package goset
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"unsafe"
+)
+
+// Union returns a new set with the items of all the given sets combined. All
+// sets must be of the same kind.
+func Union(sets ...*Set) (*Set, error) {
+	if err := matchAll(sets); err != nil {
+		return nil, err
+	}
+
+	defer rlockAll(sets)()
+
+	size := 0
+	for _, s := range sets {
+		size += len(s.m)
+	}
+
+	u := NewWithOptions(sets[0].kind, WithCapacity(size))
+	for _, s := range sets {
+		for item := range s.m {
+			u.insert(item)
+		}
+	}
+	return u, nil
+}
+
+// Intersection returns a new set with the items that are members of all the
+// given sets. All sets must be of the same kind. Only the smallest set is
+// iterated over.
+func Intersection(sets ...*Set) (*Set, error) {
+	if err := matchAll(sets); err != nil {
+		return nil, err
+	}
+
+	defer rlockAll(sets)()
+
+	sorted := slices.Clone(sets)
+	slices.SortFunc(sorted, func(a, b *Set) int {
+		return cmp.Compare(len(a.m), len(b.m))
+	})
+
+	u := New(sets[0].kind)
+	for item := range sorted[0].m {
+		if containedInAll(item, sorted[1:]) {
+			u.insert(item)
+		}
+	}
+	return u, nil
+}
+
+// Difference returns a new set with the items of the first set that are not
+// members of any of the others. All sets must be of the same kind.
+func Difference(sets ...*Set) (*Set, error) {
+	if err := matchAll(sets); err != nil {
+		return nil, err
+	}
+
+	defer rlockAll(sets)()
+
+	u := New(sets[0].kind)
+	for item := range sets[0].m {
+		if !containedInAny(item, sets[1:]) {
+			u.insert(item)
+		}
+	}
+	return u, nil
+}
+
+func containedInAll(item interface{}, sets []*Set) bool {
+	for _, s := range sets {
+		if !s.contains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+func containedInAny(item interface{}, sets []*Set) bool {
+	for _, s := range sets {
+		if s.contains(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchAll returns an error if no sets are given or if they're not all of the
+// same kind.
+func matchAll(sets []*Set) error {
+	if len(sets) == 0 {
+		return errors.New("cannot perform the requested operation on no sets")
+	}
+	for _, t := range sets[1:] {
+		if err := sets[0].typematch(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rlockAll acquires the read locks of the given sets and returns a function
+// releasing them. Locks are acquired in address order, and once per set, so
+// that concurrent calls with the same sets in any order can't deadlock.
+func rlockAll(sets []*Set) func() {
+	sorted := slices.Clone(sets)
+	slices.SortFunc(sorted, byAddress)
+	sorted = slices.Compact(sorted)
+
+	for _, s := range sorted {
+		s.rlock()
+	}
+	return func() {
+		for _, s := range sorted {
+			s.l.RUnlock()
+		}
+	}
+}
+
+// lockWith acquires the write lock of w and the read lock of r and returns a
+// function releasing them. The locks are acquired in the same address order as
+// rlockAll, and only the write lock is taken if w and r are the same set.
+func lockWith(w, r *Set) func() {
+	if w == r {
+		w.lock()
+		return w.unlock
+	}
+
+	if byAddress(w, r) < 0 {
+		w.lock()
+		r.rlock()
+	} else {
+		r.rlock()
+		w.lock()
+	}
+	return func() {
+		r.l.RUnlock()
+		w.unlock()
+	}
+}
+
+// byAddress orders sets by their address, which is the global order in which
+// locks of several sets are acquired.
+func byAddress(a, b *Set) int {
+	return cmp.Compare(uintptr(unsafe.Pointer(a)), uintptr(unsafe.Pointer(b)))
+}
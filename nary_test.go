@@ -0,0 +1,78 @@
+package goset
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestUnion(t *testing.T) {
+	a := New(reflect.Int, 1, 2)
+	b := New(reflect.Int, 2, 3)
+	c := New(reflect.Int, 4)
+
+	u, err := Union(a, b, c, a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := u.IsEqual(New(reflect.Int, 1, 2, 3, 4)); !ok {
+		t.Errorf("Union: expected [1 2 3 4], got %s", u)
+	}
+
+	if _, err := Union(a, New(reflect.String)); err == nil {
+		t.Error("Union: sets of mismatched kinds should return an error")
+	}
+	if _, err := Union(); err == nil {
+		t.Error("Union: no sets should return an error")
+	}
+}
+
+func TestIntersection(t *testing.T) {
+	a := New(reflect.Int, 1, 2, 3, 4)
+	b := New(reflect.Int, 2, 3, 4)
+	c := New(reflect.Int, 3, 4, 5)
+
+	u, err := Intersection(a, b, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := u.IsEqual(New(reflect.Int, 3, 4)); !ok {
+		t.Errorf("Intersection: expected [3 4], got %s", u)
+	}
+
+	u, _ = Intersection(a)
+	if ok, _ := u.IsEqual(a); !ok {
+		t.Errorf("Intersection: a single set should be copied, got %s", u)
+	}
+}
+
+func TestDifference(t *testing.T) {
+	a := New(reflect.Int, 1, 2, 3, 4)
+	b := New(reflect.Int, 2)
+	c := New(reflect.Int, 4, 5)
+
+	u, err := Difference(a, b, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := u.IsEqual(New(reflect.Int, 1, 3)); !ok {
+		t.Errorf("Difference: expected [1 3], got %s", u)
+	}
+	if a.Size() != 4 {
+		t.Error("Difference: the sets should not be modified")
+	}
+}
+
+func TestUnion_concurrent(t *testing.T) {
+	a := New(reflect.Int, 1)
+	b := New(reflect.Int, 2)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(3)
+		go func() { defer wg.Done(); Union(a, b) }()
+		go func() { defer wg.Done(); Intersection(b, a) }()
+		go func() { defer wg.Done(); a.Add(i); b.Add(i) }()
+	}
+	wg.Wait()
+}
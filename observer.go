@@ -0,0 +1,168 @@
+package goset
+
+import "sync/atomic"
+
+// ChangeOp is the kind of change described by a ChangeEvent.
+type ChangeOp int
+
+const (
+	// OpAdd reports items added to the set.
+	OpAdd ChangeOp = iota + 1
+
+	// OpRemove reports items removed from the set.
+	OpRemove
+
+	// OpClear reports items removed from the set by Clear.
+	OpClear
+)
+
+// String returns the name of the operation.
+func (op ChangeOp) String() string {
+	switch op {
+	case OpAdd:
+		return "add"
+	case OpRemove:
+		return "remove"
+	case OpClear:
+		return "clear"
+	}
+	return "unknown"
+}
+
+// ChangeEvent describes the items added or removed by an operation on a set.
+type ChangeEvent struct {
+	Op    ChangeOp
+	Items []interface{}
+}
+
+// observer is a callback registered with OnAdd, OnRemove, Watch or
+// OnSizeThreshold. fn is called for every event, resized once per operation
+// with the size of the set before and after it.
+type observer struct {
+	id      uint64 // unique among all sets, see ObserverIDs
+	fn      func(ev ChangeEvent)
+	resized func(before, after int)
+}
+
+// lastObserverID is the id of the last observer registered on any set.
+var lastObserverID uint64
+
+// OnAdd registers fn to be called with the items added to s by every
+// operation that adds any, including bulk operations such as Merge. fn is
+// called after the lock of s has been released, so it may use s, and it must
+// not modify the items slice. The returned function unregisters fn.
+func (s *Set) OnAdd(fn func(items []interface{})) (cancel func()) {
+	return s.observe(&observer{fn: func(ev ChangeEvent) {
+		if ev.Op == OpAdd {
+			fn(ev.Items)
+		}
+	}})
+}
+
+// OnRemove registers fn to be called with the items removed from s by every
+// operation that removes any, including bulk operations such as Separate and
+// Clear. fn is called after the lock of s has been released, so it may use s,
+// and it must not modify the items slice. The returned function unregisters
+// fn.
+func (s *Set) OnRemove(fn func(items []interface{})) (cancel func()) {
+	return s.observe(&observer{fn: func(ev ChangeEvent) {
+		if ev.Op == OpRemove || ev.Op == OpClear {
+			fn(ev.Items)
+		}
+	}})
+}
+
+// OnSizeThreshold registers fn to be called with the size of s whenever an
+// operation makes the size cross n, in either direction: when it grows from
+// below n to n or more, and when it shrinks from n or more to below n. fn is
+// called after the lock of s has been released. The returned function
+// unregisters fn.
+func (s *Set) OnSizeThreshold(n int, fn func(size int)) (cancel func()) {
+	return s.observe(&observer{resized: func(before, after int) {
+		if (before < n) != (after < n) {
+			fn(after)
+		}
+	}})
+}
+
+// ObserverIDs returns the ids of the observers of s that are still
+// registered, in the order they were registered: the callbacks of OnAdd,
+// OnRemove and OnSizeThreshold that were not cancelled, and the watches of
+// Watch and ForEachMember that didn't stop yet. Ids are never reused, even
+// across sets. It's meant for leak checks, see settest.VerifyNoLeaks.
+func (s *Set) ObserverIDs() []uint64 {
+	s.rlock()
+	defer s.l.RUnlock()
+
+	ids := make([]uint64, len(s.observers))
+	for i, o := range s.observers {
+		ids[i] = o.id
+	}
+	return ids
+}
+
+func (s *Set) observe(o *observer) func() {
+	o.id = atomic.AddUint64(&lastObserverID, 1)
+
+	s.lock()
+	defer s.l.Unlock()
+
+	s.observers = append(s.observers, o)
+
+	return func() {
+		s.lock()
+		defer s.l.Unlock()
+
+		observers := make([]*observer, 0, len(s.observers))
+		for _, other := range s.observers {
+			if other != o {
+				observers = append(observers, other)
+			}
+		}
+		s.observers = observers
+	}
+}
+
+// unlock releases the write lock of s and then notifies the observers about
+// the items added and removed while it was held, removals first. Changes are
+// written to the write-ahead log of s, if any, before the lock is released.
+// Mutating operations release the lock with it instead of s.l.Unlock.
+func (s *Set) unlock() {
+	s.flushWAL() // failures are reported by the next method returning an error
+
+	added, removed, cleared, observers := s.added, s.removed, s.cleared, s.observers
+	s.added, s.removed, s.cleared = nil, nil, false
+	size := len(s.m)
+	s.l.Unlock()
+
+	if len(observers) == 0 || len(added)+len(removed) == 0 {
+		return
+	}
+
+	events := make([]ChangeEvent, 0, 2)
+	if len(removed) > 0 {
+		op := OpRemove
+		if cleared {
+			op = OpClear
+		}
+		events = append(events, ChangeEvent{Op: op, Items: removed})
+	}
+	if len(added) > 0 {
+		events = append(events, ChangeEvent{Op: OpAdd, Items: added})
+	}
+
+	for _, ev := range events {
+		for _, o := range observers {
+			if o.fn != nil {
+				o.fn(ev)
+			}
+		}
+	}
+
+	before := size - len(added) + len(removed)
+	for _, o := range observers {
+		if o.resized != nil {
+			o.resized(before, size)
+		}
+	}
+}
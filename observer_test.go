@@ -0,0 +1,103 @@
+package goset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSet_OnAdd(t *testing.T) {
+	s := New(reflect.Int, 1)
+
+	var calls [][]interface{}
+	cancel := s.OnAdd(func(items []interface{}) {
+		calls = append(calls, items)
+		s.Size() // the lock must be released
+	})
+
+	s.Add(1, 2, 3)
+	s.Add(1)
+	s.Merge(New(reflect.Int, 3, 4))
+	s.SymmetricDifferenceUpdate(New(reflect.Int, 4, 5))
+
+	expected := [][]interface{}{{2, 3}, {4}, {5}}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("OnAdd: expected calls %v, got %v", expected, calls)
+	}
+
+	cancel()
+	s.Add(6)
+	if len(calls) != 3 {
+		t.Error("OnAdd: a cancelled observer should not be called")
+	}
+}
+
+func TestSet_OnRemove(t *testing.T) {
+	s := New(reflect.Int, 1, 2, 3, 4, 5)
+
+	removed := New(reflect.Int)
+	calls := 0
+	s.OnRemove(func(items []interface{}) {
+		calls++
+		removed.Add(items...)
+	})
+	s.OnAdd(func(items []interface{}) {
+		t.Error("OnRemove: removals should not call OnAdd observers")
+	})
+
+	s.Remove(1, 9)
+	s.Separate(New(reflect.Int, 2))
+	s.RemoveReport(3)
+	s.Clear()
+
+	if calls != 4 || removed.Size() != 5 {
+		t.Errorf("OnRemove: expected four calls with all five items, got %d calls with %s", calls, removed)
+	}
+
+	s.Clear()
+	if calls != 4 {
+		t.Error("OnRemove: clearing an empty set should not call observers")
+	}
+}
+
+func TestSet_OnAdd_tx(t *testing.T) {
+	s := New(reflect.String, "a")
+
+	var added, removed []interface{}
+	s.OnAdd(func(items []interface{}) { added = items })
+	s.OnRemove(func(items []interface{}) { removed = items })
+
+	s.Do(func(tx SetTx) {
+		tx.Remove("a")
+		tx.Add("b", "c")
+	})
+
+	if len(added) != 2 || len(removed) != 1 {
+		t.Errorf("OnAdd: a transaction should notify once for each change, got %v and %v", added, removed)
+	}
+}
+
+func TestSet_OnSizeThreshold(t *testing.T) {
+	s := New(reflect.Int, 1)
+
+	var sizes []int
+	cancel := s.OnSizeThreshold(3, func(size int) { sizes = append(sizes, size) })
+
+	s.Add(2)       // 2, below
+	s.Add(3, 4)    // 4, rising
+	s.Add(5)       // 5, above
+	s.Remove(5)    // 4, above
+	s.Remove(4, 3) // 2, falling
+	s.Add(3)       // 3, rising
+	s.Clear()      // 0, falling
+
+	expected := []int{4, 2, 3, 0}
+	if !reflect.DeepEqual(sizes, expected) {
+		t.Errorf("OnSizeThreshold: expected calls with %v, got %v", expected, sizes)
+	}
+
+	cancel()
+	s.Add(1, 2, 3)
+	if len(sizes) != 4 {
+		t.Error("OnSizeThreshold: a cancelled callback should not be called")
+	}
+}
@@ -0,0 +1,161 @@
+package goset
+
+import "reflect"
+
+// Option configures a Set created with NewWithOptions.
+type Option func(*options)
+
+type options struct {
+	name     string
+	capacity int
+	items    []interface{}
+	ids      bool
+	pool     *InternPool
+	metrics  bool
+	journal  bool
+	retain   bool
+	locking  LockStrategy
+	sorted   bool
+	flagSep  *string
+}
+
+// WithName gives the set a name, returned by Name. It's useful to tell sets
+// apart in logs and diagnostics.
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
+
+// WithCapacity pre-sizes the set to hold n items without growing.
+func WithCapacity(n int) Option {
+	return func(o *options) {
+		o.capacity = n
+	}
+}
+
+// WithIDs assigns every item a stable small integer ID when it's inserted
+// for the first time, see IDOf and ItemOf. IDs start at zero and are never
+// reused: an item that is removed and added again gets its old ID back. The
+// set keeps every item it has ever held to provide this guarantee.
+func WithIDs() Option {
+	return func(o *options) {
+		o.ids = true
+	}
+}
+
+// WithInternPool stores the string items of the set in pool, so that equal
+// strings held by many sets sharing the pool are kept in memory once. Items
+// of other types are stored as usual.
+func WithInternPool(pool *InternPool) Option {
+	return func(o *options) {
+		o.pool = pool
+	}
+}
+
+// WithMetrics enables the instrumentation of the set, see Set.Metrics. It
+// costs a few atomic operations per call.
+func WithMetrics() Option {
+	return func(o *options) {
+		o.metrics = true
+	}
+}
+
+// WithFlagSeparator sets the separator used to split the values passed to
+// Set when the set is used as a flag.Value. It's a comma by default. An empty
+// separator disables splitting, so every occurrence of the flag adds exactly
+// one item.
+func WithFlagSeparator(sep string) Option {
+	return func(o *options) {
+		o.flagSep = &sep
+	}
+}
+
+// WithJournal records every change of the set, so that it can be reverted
+// with Undo and RollbackTo. The journal grows with every item added or
+// removed until it's discarded with ForgetJournal.
+func WithJournal() Option {
+	return func(o *options) {
+		o.journal = true
+	}
+}
+
+// WithRetainCapacity makes Clear keep the memory of the backing map, so that
+// a set that is cleared and refilled repeatedly doesn't reallocate it. Use
+// Compact to release it.
+func WithRetainCapacity() Option {
+	return func(o *options) {
+		o.retain = true
+	}
+}
+
+// WithLocking sets the concurrency strategy of the set, see LockStrategy. It's
+// LockRW by default.
+func WithLocking(strategy LockStrategy) Option {
+	return func(o *options) {
+		o.locking = strategy
+	}
+}
+
+// WithSortedOutput makes String and MarshalJSON list the items of the set in
+// sorted order, so that equal sets have the same output. It's useful for
+// golden tests and for hashing or caching on the content of sets.
+func WithSortedOutput() Option {
+	return func(o *options) {
+		o.sorted = true
+	}
+}
+
+// WithItems populates the set with the given items. The items of all
+// WithItems options are added at once: if any of them doesn't match the kind
+// of the set, none are added and the set starts empty, as with New.
+func WithItems(items ...interface{}) Option {
+	return func(o *options) {
+		o.items = append(o.items, items...)
+	}
+}
+
+// NewWithOptions creates and initializes a new Set of the given kind,
+// configured by opts. Options are applied in order, later options override
+// earlier ones.
+func NewWithOptions(kind reflect.Kind, opts ...Option) *Set {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	capacity := o.capacity
+	if capacity < len(o.items) {
+		capacity = len(o.items)
+	}
+
+	s := &Set{
+		kind: kind,
+		name: o.name,
+		pool: o.pool,
+		m:    make(map[interface{}]struct{}, capacity), // struct{} doesn't take up space
+		l:    setLock{strategy: o.locking},
+
+		retainCapacity: o.retain,
+		sortedOutput:   o.sorted,
+	}
+
+	if o.metrics {
+		s.metrics = &metrics{}
+	}
+	if o.flagSep != nil {
+		s.flagSep = *o.flagSep
+		s.flagNoSplit = *o.flagSep == ""
+	}
+	if o.ids {
+		s.ids = make(map[interface{}]int)
+	}
+
+	s.Add(o.items...)
+
+	// the initial items are not a change that can be undone
+	if o.journal {
+		s.journal = &journal{}
+	}
+	return s
+}
@@ -0,0 +1,40 @@
+package goset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewWithOptions(t *testing.T) {
+	s := NewWithOptions(reflect.String,
+		WithName("cities"),
+		WithCapacity(16),
+		WithItems("ankara", "berlin"),
+		WithItems("istanbul"),
+	)
+
+	if s.Name() != "cities" {
+		t.Error("NewWithOptions: the set should be named 'cities'")
+	}
+
+	if s.Kind() != reflect.String {
+		t.Error("NewWithOptions: the set should be of kind string")
+	}
+
+	if ok, _ := s.Has("ankara", "berlin", "istanbul"); !ok || s.Size() != 3 {
+		t.Error("NewWithOptions: items passed with WithItems are not available in the set")
+	}
+
+	s = NewWithOptions(reflect.String, WithItems("ankara"), WithItems(1))
+	if !s.IsEmpty() {
+		t.Errorf("NewWithOptions: no items should be added if one doesn't match the kind, got %s", s)
+	}
+}
+
+func TestNewWithOptions_empty(t *testing.T) {
+	s := NewWithOptions(reflect.Int)
+
+	if s.Size() != 0 || s.Name() != "" {
+		t.Error("NewWithOptions: calling without options should create an empty unnamed set")
+	}
+}
@@ -0,0 +1,156 @@
+package goset
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// compare orders two items of the same kind. Numbers, strings and booleans
+// (false before true) are compared by value, any other kind by its %v
+// representation. It returns -1, 0 or +1.
+func compare(a, b interface{}) int {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Kind() == vb.Kind() {
+		switch va.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return cmp.Compare(va.Int(), vb.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return cmp.Compare(va.Uint(), vb.Uint())
+		case reflect.Float32, reflect.Float64:
+			return cmp.Compare(va.Float(), vb.Float())
+		case reflect.String:
+			return cmp.Compare(va.String(), vb.String())
+		case reflect.Bool:
+			return cmp.Compare(boolInt(va.Bool()), boolInt(vb.Bool()))
+		}
+	}
+	return cmp.Compare(fmt.Sprintf("%v", a), fmt.Sprintf("%v", b))
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// SortedSliceFunc returns all items of s ordered by less.
+func (s *Set) SortedSliceFunc(less func(a, b interface{}) bool) []interface{} {
+	list := s.List()
+	sort.Slice(list, func(i, j int) bool {
+		return less(list[i], list[j])
+	})
+	return list
+}
+
+// sortedList returns all items of s ordered by compare.
+func (s *Set) sortedList() []interface{} {
+	return s.SortedSliceFunc(func(a, b interface{}) bool {
+		return compare(a, b) < 0
+	})
+}
+
+// Min returns the smallest item of s. It returns an error if s is empty or if
+// its kind is not a number or a string kind.
+func (s *Set) Min() (interface{}, error) {
+	return s.extreme("minimum", -1)
+}
+
+// Max returns the largest item of s. It returns an error if s is empty or if
+// its kind is not a number or a string kind.
+func (s *Set) Max() (interface{}, error) {
+	return s.extreme("maximum", +1)
+}
+
+// extreme returns the item of s for which compare with every other item
+// returns sign, under a single read lock.
+func (s *Set) extreme(name string, sign int) (interface{}, error) {
+	if !isOrdered(s.kind) {
+		return nil, fmt.Errorf("cannot take the %s of a set of kind '%s'", name, s.kind)
+	}
+
+	s.rlock()
+	defer s.l.RUnlock()
+
+	var (
+		res   interface{}
+		found bool
+	)
+	for item := range s.m {
+		if !found || compare(item, res) == sign {
+			res, found = item, true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("cannot take the %s of an empty set", name)
+	}
+	return res, nil
+}
+
+// isOrdered reports whether items of the given kind are ordered by compare
+// in their natural order.
+func isOrdered(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.String:
+		return true
+	}
+	return false
+}
+
+// RangeQuery returns the items of s between from and to, in ascending order.
+// The bounds are included if inclusive is true, excluded otherwise. Items are
+// ordered as by Min and Max; time.Time items are ordered chronologically, see
+// also Between. It returns an error if the bounds don't match the kind of s,
+// if the kind of s is not a number or a string kind and the bounds aren't
+// times, or if the bounds are times and s holds an item that isn't one.
+//
+// The set is not kept sorted, so every query scans all items.
+func (s *Set) RangeQuery(from, to interface{}, inclusive bool) ([]interface{}, error) {
+	if err := s.typecheck(from, to); err != nil {
+		return nil, err
+	}
+	from, to = normalize(from), normalize(to)
+
+	_, fromTime := from.(time.Time)
+	_, toTime := to.(time.Time)
+	if fromTime != toTime {
+		return nil, fmt.Errorf("range bounds %v and %v must both be times or neither", from, to)
+	}
+	if !fromTime && !isOrdered(s.kind) {
+		return nil, fmt.Errorf("cannot take a range of a set of kind '%s'", s.kind)
+	}
+
+	order := compare
+	if fromTime {
+		order = func(a, b interface{}) int {
+			return a.(time.Time).Compare(b.(time.Time))
+		}
+	}
+
+	in := func(item interface{}) bool {
+		lo, hi := order(item, from), order(item, to)
+		if inclusive {
+			return lo >= 0 && hi <= 0
+		}
+		return lo > 0 && hi < 0
+	}
+
+	items := make([]interface{}, 0)
+	for _, item := range s.List() {
+		if _, ok := item.(time.Time); fromTime && !ok {
+			return nil, fmt.Errorf("cannot compare %v of type %T to time range bounds", item, item)
+		}
+		if in(item) {
+			items = append(items, item)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return order(items[i], items[j]) < 0
+	})
+	return items, nil
+}
@@ -0,0 +1,105 @@
+package goset
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSet_SortedSliceFunc(t *testing.T) {
+	s := New(reflect.String, "ccc", "a", "bb")
+
+	byLen := s.SortedSliceFunc(func(a, b interface{}) bool {
+		return len(a.(string)) > len(b.(string))
+	})
+
+	if !reflect.DeepEqual(byLen, []interface{}{"ccc", "bb", "a"}) {
+		t.Errorf("SortedSliceFunc: unexpected order %v", byLen)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b interface{}
+		cmp  int
+	}{
+		{2, 10, -1},
+		{int64(-1), int64(-1), 0},
+		{uint8(200), uint8(100), 1},
+		{1.5, 0.5, 1},
+		{"10", "2", -1},
+		{false, true, -1},
+		{[2]int{1, 2}, [2]int{1, 3}, -1},
+	}
+
+	for _, test := range tests {
+		if c := compare(test.a, test.b); c != test.cmp {
+			t.Errorf("compare: %v and %v expected %d, got %d", test.a, test.b, test.cmp, c)
+		}
+	}
+}
+
+func TestSet_MinMax(t *testing.T) {
+	s := New(reflect.Int, 3, -1, 7, 2)
+	if min, err := s.Min(); err != nil || min != -1 {
+		t.Errorf("Min: expected -1, got %v, %v", min, err)
+	}
+	if max, err := s.Max(); err != nil || max != 7 {
+		t.Errorf("Max: expected 7, got %v, %v", max, err)
+	}
+
+	w := New(reflect.String, "pear", "apple", "zucchini")
+	if min, _ := w.Min(); min != "apple" {
+		t.Errorf("Min: expected apple, got %v", min)
+	}
+	if max, _ := w.Max(); max != "zucchini" {
+		t.Errorf("Max: expected zucchini, got %v", max)
+	}
+
+	if _, err := New(reflect.Int).Min(); err == nil {
+		t.Error("Min: an empty set should return an error")
+	}
+	if _, err := New(reflect.Bool, true).Max(); err == nil {
+		t.Error("Max: a set of an unordered kind should return an error")
+	}
+}
+
+func TestSet_RangeQuery(t *testing.T) {
+	s := New(reflect.Int, 5, 1, 3, 2, 4)
+
+	items, err := s.RangeQuery(2, 4, true)
+	if err != nil || !reflect.DeepEqual(items, []interface{}{2, 3, 4}) {
+		t.Errorf("RangeQuery: expected [2 3 4], got %v, %v", items, err)
+	}
+	items, _ = s.RangeQuery(2, 4, false)
+	if !reflect.DeepEqual(items, []interface{}{3}) {
+		t.Errorf("RangeQuery: expected [3], got %v", items)
+	}
+
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	times := New(reflect.Struct, base, base.Add(time.Hour), base.Add(2*time.Hour))
+	items, _ = times.RangeQuery(base.Add(time.Minute), base.Add(2*time.Hour), true)
+	if len(items) != 2 || !items[0].(time.Time).Equal(base.Add(time.Hour)) {
+		t.Errorf("RangeQuery: expected the last two times, got %v", items)
+	}
+
+	if _, err := s.RangeQuery("a", "b", true); err == nil {
+		t.Error("RangeQuery: bounds of another kind should return an error")
+	}
+
+	times.Add(Pair{1, 2})
+	if _, err := times.RangeQuery(base, base.Add(time.Hour), true); err == nil {
+		t.Error("RangeQuery: items that aren't times should return an error for time bounds")
+	}
+	if _, err := times.RangeQuery(base, Pair{1, 2}, true); err == nil {
+		t.Error("RangeQuery: mixing a time and another bound should return an error")
+	}
+
+	c := New(reflect.Complex128, 1+5i, 2+0i, 10+0i)
+	if items, err := c.RangeQuery(1+0i, 3+0i, true); err == nil {
+		t.Errorf("RangeQuery: unordered kinds should return an error, got %v", items)
+	}
+	if items, err := New(reflect.Struct, Pair{1, 2}).RangeQuery(Pair{0, 0}, Pair{3, 3}, true); err == nil {
+		t.Errorf("RangeQuery: struct bounds that aren't times should return an error, got %v", items)
+	}
+}
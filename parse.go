@@ -0,0 +1,46 @@
+package goset
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ParseString creates a set of the given kind from a delimited string, such
+// as "1,2,2,3" with sep ",". Every element is trimmed of surrounding
+// whitespace and converted to kind, empty elements are ignored. An empty sep
+// parses the whole string as a single element.
+func ParseString(s, sep string, kind reflect.Kind) (*Set, error) {
+	if !IsSupportedKind(kind) {
+		return nil, fmt.Errorf("set kind '%s' is not supported", kind.String())
+	}
+
+	items, err := parseItems(s, sep, kind)
+	if err != nil {
+		return nil, err
+	}
+	return New(kind, items...), nil
+}
+
+// parseItems splits s on sep, unless sep is empty, and converts the trimmed,
+// non empty elements to kind.
+func parseItems(s, sep string, kind reflect.Kind) ([]interface{}, error) {
+	parts := []string{s}
+	if sep != "" {
+		parts = strings.Split(s, sep)
+	}
+
+	items := make([]interface{}, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		item, err := convert(kind, part)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
@@ -0,0 +1,32 @@
+package goset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseString(t *testing.T) {
+	s, err := ParseString("1,2, 2,,3", ",", reflect.Int)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := s.IsEqual(New(reflect.Int, 1, 2, 3)); !ok {
+		t.Errorf("ParseString: expected [1 2 3], got %s", s)
+	}
+
+	s, err = ParseString("a b", "", reflect.String)
+	if err != nil || s.Size() != 1 {
+		t.Errorf("ParseString: an empty separator should not split, got %s", s)
+	}
+
+	if s, err := ParseString("", ",", reflect.String); err != nil || !s.IsEmpty() {
+		t.Error("ParseString: an empty string should return an empty set")
+	}
+
+	if _, err := ParseString("1,x", ",", reflect.Int); err == nil {
+		t.Error("ParseString: elements that can't be converted should return an error")
+	}
+	if _, err := ParseString("a", ",", reflect.Slice); err == nil {
+		t.Error("ParseString: unsupported kinds should return an error")
+	}
+}
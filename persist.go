@@ -0,0 +1,106 @@
+package goset
+
+import (
+	"encoding/gob"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"time"
+)
+
+func init() {
+	// item types of this package that are stored in sets
+	gob.Register(time.Time{})
+	gob.Register(Pair{})
+}
+
+// persistVersion is the version of the format written by Save.
+const persistVersion = 1
+
+// persisted is the gob encoded form of a set.
+type persisted struct {
+	Version int
+	Kind    uint
+	Name    string
+	Items   []interface{}
+}
+
+// Save writes s, including its kind and name, to w in a binary format that
+// Load reads back. Items are encoded with encoding/gob: items of named or
+// struct types must be registered with gob.Register by the caller.
+func (s *Set) Save(w io.Writer) error {
+	return s.save(w, s.List())
+}
+
+// save writes s with the given items to w, see Save.
+func (s *Set) save(w io.Writer, items []interface{}) error {
+	p := persisted{
+		Version: persistVersion,
+		Kind:    uint(s.kind),
+		Name:    s.name,
+		Items:   items,
+	}
+	if err := gob.NewEncoder(w).Encode(p); err != nil {
+		return fmt.Errorf("goset: saving set: %s", err)
+	}
+	return nil
+}
+
+// Load reads a set written by Save from r.
+func Load(r io.Reader) (*Set, error) {
+	var p persisted
+	if err := gob.NewDecoder(r).Decode(&p); err != nil {
+		return nil, fmt.Errorf("goset: loading set: %s", err)
+	}
+	if p.Version != persistVersion {
+		return nil, fmt.Errorf("goset: loading set: unsupported version %d", p.Version)
+	}
+
+	s := NewWithOptions(reflect.Kind(p.Kind), WithName(p.Name))
+	if err := s.Add(p.Items...); err != nil {
+		return nil, fmt.Errorf("goset: loading set: %s", err)
+	}
+	return s, nil
+}
+
+// SaveFile saves s to the file at path, see Save. The file is replaced
+// atomically: it's written to a temporary file first, then renamed.
+func (s *Set) SaveFile(path string) error {
+	return writeFileAtomic(path, s.Save)
+}
+
+// writeFileAtomic replaces the file at path with the output of write. The
+// output is written to a temporary file first, which is renamed once synced.
+func writeFileAtomic(path string, write func(w io.Writer) error) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name()) // no-op once renamed
+
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// LoadFile loads a set saved with SaveFile from the file at path.
+func LoadFile(path string) (*Set, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Load(f)
+}
@@ -0,0 +1,83 @@
+package goset
+
+import (
+	"bytes"
+	"encoding/gob"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSet_Save(t *testing.T) {
+	tests := []*Set{
+		NewWithOptions(reflect.String, WithName("cities"), WithItems("ankara", "berlin")),
+		New(reflect.Int64, int64(1), int64(2)),
+		New(reflect.Struct, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)),
+		New(reflect.Int),
+	}
+
+	for _, s := range tests {
+		var buf bytes.Buffer
+		if err := s.Save(&buf); err != nil {
+			t.Fatalf("Save: unexpected error: %s", err)
+		}
+
+		u, err := Load(&buf)
+		if err != nil {
+			t.Fatalf("Load: unexpected error: %s", err)
+		}
+
+		if ok, err := s.IsEqual(u); !ok || err != nil || u.Name() != s.Name() {
+			t.Errorf("Load: expected %s, got %s (%v)", s, u, err)
+		}
+	}
+}
+
+func TestSet_Save_struct(t *testing.T) {
+	type point struct{ X, Y int }
+	gob.Register(point{})
+
+	s := New(reflect.Struct, point{1, 2})
+
+	var buf bytes.Buffer
+	s.Save(&buf)
+
+	u, err := Load(&buf)
+	if err != nil {
+		t.Fatalf("Load: unexpected error: %s", err)
+	}
+	if ok, _ := u.Has(point{1, 2}); !ok {
+		t.Error("Load: registered struct items should be restored")
+	}
+}
+
+func TestSet_SaveFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "set.gob")
+
+	s := New(reflect.String, "a", "b")
+	if err := s.SaveFile(path); err != nil {
+		t.Fatalf("SaveFile: unexpected error: %s", err)
+	}
+
+	s.Add("c")
+	if err := s.SaveFile(path); err != nil {
+		t.Fatalf("SaveFile: overwriting failed: %s", err)
+	}
+
+	u, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile: unexpected error: %s", err)
+	}
+	if ok, _ := u.IsEqual(s); !ok {
+		t.Errorf("LoadFile: expected %s, got %s", s, u)
+	}
+
+	if _, err := LoadFile(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("LoadFile: a missing file should return an error")
+	}
+
+	if _, err := Load(bytes.NewReader([]byte("garbage"))); err == nil {
+		t.Error("Load: invalid data should return an error")
+	}
+}
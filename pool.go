@@ -0,0 +1,71 @@
+package goset
+
+import (
+	"reflect"
+	"sync"
+)
+
+// setPools hold released sets by kind, see Acquire.
+var setPools [reflect.UnsafePointer + 1]sync.Pool
+
+// maxPooledSize is the largest number of items a released set may have held
+// to be reused, so that pools don't keep the memory of a few huge sets.
+const maxPooledSize = 1 << 12
+
+// Acquire returns an empty Set of the given kind, reusing one given back with
+// Release if possible. It's meant for short-lived sets created at a high
+// rate, to spare the allocation of their backing map. Sets from Acquire have
+// no options set.
+func Acquire(kind reflect.Kind) *Set {
+	if kind >= 0 && int(kind) < len(setPools) {
+		if s, ok := setPools[kind].Get().(*Set); ok {
+			return s
+		}
+	}
+	return New(kind)
+}
+
+// Release gives s back to be reused by Acquire. s is reset first, dropping
+// its items, options and observers; it must not be used anymore afterwards.
+// Sets that held many items are not kept, nor are sets with observers, which
+// may still refer to them.
+//
+// The strings of s are released from its InternPool, if any, and a set created
+// with NewLazy that wasn't accessed yet isn't populated. The write-ahead log of
+// s, if any, is written out and closed, and s is not kept either. Call Close
+// before Release to get the error of the log.
+func Release(s *Set) {
+	if s.lazy != nil {
+		s.lazy.once.Do(func() {}) // don't fill the set only to drop its items
+	}
+
+	s.lock()
+	if s.pool != nil {
+		for item := range s.m {
+			s.pool.release(item)
+		}
+		s.pool = nil
+	}
+	if s.wal != nil {
+		s.flushWAL()
+		s.wal.f.Close()
+		s.wal = nil
+		s.l.Unlock()
+		return
+	}
+	m, size, shared := s.m, len(s.m), s.shared
+	kind, observed := s.kind, len(s.observers) > 0
+	s.l.Unlock()
+
+	if !IsSupportedKind(kind) || size > maxPooledSize || observed {
+		return
+	}
+
+	if shared || m == nil {
+		m = make(map[interface{}]struct{})
+	} else {
+		clear(m)
+	}
+	*s = Set{kind: kind, m: m}
+	setPools[kind].Put(s)
+}
@@ -0,0 +1,92 @@
+package goset
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestAcquire(t *testing.T) {
+	s := Acquire(reflect.Int)
+	if !s.IsEmpty() || s.Kind() != reflect.Int {
+		t.Fatalf("Acquire: expected an empty int set, got %s", s)
+	}
+	s.Add(1, 2, 3)
+	f := s.Snapshot()
+	Release(s)
+
+	for i := 0; i < 10; i++ {
+		u := Acquire(reflect.Int)
+		if !u.IsEmpty() || u.Kind() != reflect.Int {
+			t.Fatalf("Acquire: expected an empty int set, got %s", u)
+		}
+		u.Add(i)
+		Release(u)
+	}
+	if f.Size() != 3 {
+		t.Error("Release: snapshots should not be modified")
+	}
+
+	if s := Acquire(reflect.String); s.Kind() != reflect.String {
+		t.Errorf("Acquire: expected a string set, got %s", s.Kind())
+	}
+}
+
+func TestRelease_wal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "set.wal")
+	s, err := NewWAL(path, reflect.Int)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Add(1, 2)
+	Release(s)
+
+	if s.wal != nil {
+		t.Error("Release: the log should be closed")
+	}
+	r, err := Recover(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if r.Size() != 2 {
+		t.Errorf("Release: the log should keep the items, got %s", r)
+	}
+}
+
+func TestRelease_internPool(t *testing.T) {
+	pool := NewInternPool()
+	s := NewWithOptions(reflect.String, WithInternPool(pool), WithItems("a", "b"))
+	u := NewWithOptions(reflect.String, WithInternPool(pool), WithItems("a"))
+
+	Release(s)
+	if pool.Len() != 1 {
+		t.Errorf("Release: the pool should only hold the strings of other sets, got %d", pool.Len())
+	}
+	u.Clear()
+	if pool.Len() != 0 {
+		t.Errorf("Release: expected an empty pool, got %d", pool.Len())
+	}
+}
+
+func TestRelease_lazy(t *testing.T) {
+	filled := false
+	s := NewLazy(reflect.Int, func(add func(items ...interface{}) error) error {
+		filled = true
+		return add(1, 2)
+	})
+
+	Release(s)
+	if filled {
+		t.Error("Release: a set that wasn't accessed should not be populated")
+	}
+}
+
+func BenchmarkAcquire(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s := Acquire(reflect.Int)
+		s.Add(1, 2, 3)
+		Release(s)
+	}
+}
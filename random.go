@@ -0,0 +1,46 @@
+package goset
+
+import "math/rand"
+
+// Sample returns k distinct items of s chosen uniformly at random, in random
+// order. If k is larger than the size of s all items are returned. The items
+// are picked under a single read lock.
+func (s *Set) Sample(k int) []interface{} {
+	if k <= 0 {
+		return make([]interface{}, 0)
+	}
+
+	items := s.List()
+	if k > len(items) {
+		k = len(items)
+	}
+
+	// partial Fisher-Yates shuffle of the first k items
+	for i := 0; i < k; i++ {
+		j := i + rand.Intn(len(items)-i)
+		items[i], items[j] = items[j], items[i]
+	}
+	return items[:k:k]
+}
+
+// PopRandom removes an item chosen uniformly at random from s and returns it.
+// The returned bool is false if the set is empty.
+func (s *Set) PopRandom() (interface{}, bool) {
+	s.lock()
+	defer s.unlock()
+
+	if len(s.m) == 0 {
+		return nil, false
+	}
+
+	// map iteration order is not uniformly random, so walk to a random index
+	n := rand.Intn(len(s.m))
+	for item := range s.m {
+		if n == 0 {
+			s.delete(item)
+			return item, true
+		}
+		n--
+	}
+	panic("unreachable")
+}
@@ -0,0 +1,63 @@
+package goset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSet_Sample(t *testing.T) {
+	s := New(reflect.Int, 1, 2, 3, 4, 5)
+
+	sample := s.Sample(3)
+	if len(sample) != 3 {
+		t.Fatal("Sample: expected three items")
+	}
+
+	u := New(reflect.Int, sample...)
+	if u.Size() != 3 {
+		t.Error("Sample: items should be distinct")
+	}
+	if ok, _ := s.IsSubset(u); !ok {
+		t.Error("Sample: items should be members of the set")
+	}
+
+	if len(s.Sample(10)) != 5 || len(s.Sample(0)) != 0 {
+		t.Error("Sample: k should be bounded by the size of the set")
+	}
+}
+
+func TestSet_Sample_uniform(t *testing.T) {
+	s := New(reflect.Int, 0, 1, 2, 3)
+
+	counts := make([]int, 4)
+	for i := 0; i < 4000; i++ {
+		counts[s.Sample(1)[0].(int)]++
+	}
+
+	for item, n := range counts {
+		if n < 800 || n > 1200 {
+			t.Errorf("Sample: item %d was picked %d out of 4000 times", item, n)
+		}
+	}
+}
+
+func TestSet_PopRandom(t *testing.T) {
+	s := New(reflect.Int, 1, 2, 3)
+	popped := New(reflect.Int)
+
+	for i := 0; i < 3; i++ {
+		item, ok := s.PopRandom()
+		if !ok {
+			t.Fatal("PopRandom: the set should not be empty yet")
+		}
+		popped.Add(item)
+	}
+
+	if !s.IsEmpty() || popped.Size() != 3 {
+		t.Error("PopRandom: all items should have been popped exactly once")
+	}
+
+	if _, ok := s.PopRandom(); ok {
+		t.Error("PopRandom: popping from an empty set should return false")
+	}
+}
@@ -0,0 +1,86 @@
+package goset
+
+import "reflect"
+
+// ReadOnlySet is a view of a set that only exposes the methods that don't
+// modify it, so that code given a ReadOnlySet can't mutate the set it views.
+// Changes made to the underlying set are visible through the view. Set
+// algebra on a view returns new, independent sets.
+//
+// ReadOnlySet doesn't implement Interface, which includes Add and Remove:
+// having them, even failing at run time, would defeat the type system's
+// guarantee. Pass a Copy of the view where an Interface is needed.
+type ReadOnlySet struct {
+	s *Set
+}
+
+// ReadOnly returns a read-only view of s.
+func (s *Set) ReadOnly() ReadOnlySet {
+	return ReadOnlySet{s: s}
+}
+
+// Name is like Set.Name.
+func (r ReadOnlySet) Name() string { return r.s.Name() }
+
+// Kind is like Set.Kind.
+func (r ReadOnlySet) Kind() reflect.Kind { return r.s.Kind() }
+
+// Has is like Set.Has.
+func (r ReadOnlySet) Has(items ...interface{}) (bool, error) { return r.s.Has(items...) }
+
+// Size is like Set.Size.
+func (r ReadOnlySet) Size() int { return r.s.Size() }
+
+// IsEmpty is like Set.IsEmpty.
+func (r ReadOnlySet) IsEmpty() bool { return r.s.IsEmpty() }
+
+// List is like Set.List.
+func (r ReadOnlySet) List() []interface{} { return r.s.List() }
+
+// String is like Set.String.
+func (r ReadOnlySet) String() string { return r.s.String() }
+
+// Copy is like Set.Copy. The copy is a regular, mutable set.
+func (r ReadOnlySet) Copy() *Set { return r.s.Copy() }
+
+// Each calls fn for every item of the set, until fn returns false. The items
+// are the ones of the set when Each is called.
+func (r ReadOnlySet) Each(fn func(item interface{}) bool) {
+	for _, item := range r.s.List() {
+		if !fn(item) {
+			return
+		}
+	}
+}
+
+// Any is like Set.Any.
+func (r ReadOnlySet) Any(pred func(item interface{}) bool) bool { return r.s.Any(pred) }
+
+// All is like Set.All.
+func (r ReadOnlySet) All(pred func(item interface{}) bool) bool { return r.s.All(pred) }
+
+// IsEqual is like Set.IsEqual.
+func (r ReadOnlySet) IsEqual(t Interface) (bool, error) { return r.s.IsEqual(t) }
+
+// IsSubset is like Set.IsSubset.
+func (r ReadOnlySet) IsSubset(t Interface) (bool, error) { return r.s.IsSubset(t) }
+
+// IsSuperset is like Set.IsSuperset.
+func (r ReadOnlySet) IsSuperset(t Interface) (bool, error) { return r.s.IsSuperset(t) }
+
+// IsDisjoint is like Set.IsDisjoint.
+func (r ReadOnlySet) IsDisjoint(t Interface) (bool, error) { return r.s.IsDisjoint(t) }
+
+// Union is like Set.Union.
+func (r ReadOnlySet) Union(t Interface) (*Set, error) { return r.s.Union(t) }
+
+// Intersection is like Set.Intersection.
+func (r ReadOnlySet) Intersection(t Interface) (*Set, error) { return r.s.Intersection(t) }
+
+// Difference is like Set.Difference.
+func (r ReadOnlySet) Difference(t Interface) (*Set, error) { return r.s.Difference(t) }
+
+// SymmetricDifference is like Set.SymmetricDifference.
+func (r ReadOnlySet) SymmetricDifference(t Interface) (*Set, error) {
+	return r.s.SymmetricDifference(t)
+}
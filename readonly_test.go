@@ -0,0 +1,47 @@
+package goset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSet_ReadOnly(t *testing.T) {
+	s := New(reflect.Int, 1, 2)
+	r := s.ReadOnly()
+
+	if ok, _ := r.Has(1, 2); !ok || r.Size() != 2 || r.Kind() != reflect.Int {
+		t.Errorf("ReadOnly: expected [1 2], got %s", r)
+	}
+
+	s.Add(3)
+	if ok, _ := r.Has(3); !ok {
+		t.Error("ReadOnly: changes of the set should be visible")
+	}
+
+	u, err := r.Union(New(reflect.Int, 4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	u.Add(5)
+	if s.Size() != 3 || u.Size() != 5 {
+		t.Errorf("Union: expected a new set, got %s and %s", s, u)
+	}
+
+	sum := 0
+	r.Each(func(item interface{}) bool {
+		sum += item.(int)
+		return true
+	})
+	if sum != 6 {
+		t.Errorf("Each: expected a sum of 6, got %d", sum)
+	}
+
+	calls := 0
+	r.Each(func(item interface{}) bool {
+		calls++
+		return false
+	})
+	if calls != 1 {
+		t.Errorf("Each: should stop when fn returns false, got %d calls", calls)
+	}
+}
@@ -0,0 +1,92 @@
+package goset
+
+import "sync/atomic"
+
+// Source is an authoritative source of membership, such as a remote or
+// persistent set, consulted by a ReadRepairSet. Any Interface is a Source.
+type Source interface {
+	Has(items ...interface{}) (bool, error)
+}
+
+// ReadRepairSet is a local set caching the membership of an authoritative
+// Source, following the cache-aside pattern: when an item is missing from the
+// local set, the source is consulted and, if the item is a member there, it's
+// added to the local set so that later lookups are served locally.
+type ReadRepairSet struct {
+	local  *Set
+	source Source
+
+	repair atomic.Bool
+
+	hits    atomic.Int64
+	misses  atomic.Int64
+	repairs atomic.Int64
+}
+
+// RepairStats are the counters of a ReadRepairSet. They're cumulative since
+// its creation.
+type RepairStats struct {
+	Hits    int64 // items found in the local set
+	Misses  int64 // items missing from the local set
+	Repairs int64 // missing items found in the source and back-filled
+}
+
+// NewReadRepair returns a ReadRepairSet caching the membership of source in
+// local. Read repair is enabled.
+func NewReadRepair(local *Set, source Source) *ReadRepairSet {
+	r := &ReadRepairSet{local: local, source: source}
+	r.repair.Store(true)
+	return r
+}
+
+// SetRepair enables or disables consulting the source on local misses. While
+// it's disabled, Has only reports the membership in the local set.
+func (r *ReadRepairSet) SetRepair(enabled bool) {
+	r.repair.Store(enabled)
+}
+
+// Local returns the local set.
+func (r *ReadRepairSet) Local() *Set {
+	return r.local
+}
+
+// Has looks for the existence of items passed, in the local set first and in
+// the source for the items missing locally. It returns false if nothing is
+// passed. For multiple items it returns true only if all of the items exist.
+// Errors of the source are returned.
+func (r *ReadRepairSet) Has(items ...interface{}) (bool, error) {
+	if len(items) == 0 {
+		return false, nil
+	}
+	if err := r.local.typecheck(items...); err != nil {
+		return false, err
+	}
+
+	for _, item := range items {
+		if ok, _ := r.local.Has(item); ok {
+			r.hits.Add(1)
+			continue
+		}
+		r.misses.Add(1)
+
+		if !r.repair.Load() {
+			return false, nil
+		}
+		ok, err := r.source.Has(item)
+		if err != nil || !ok {
+			return false, err
+		}
+		r.local.Add(item)
+		r.repairs.Add(1)
+	}
+	return true, nil
+}
+
+// Stats returns the current counters of r.
+func (r *ReadRepairSet) Stats() RepairStats {
+	return RepairStats{
+		Hits:    r.hits.Load(),
+		Misses:  r.misses.Load(),
+		Repairs: r.repairs.Load(),
+	}
+}
@@ -0,0 +1,47 @@
+package goset
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type failingSource struct{}
+
+func (failingSource) Has(items ...interface{}) (bool, error) {
+	return false, errors.New("unavailable")
+}
+
+func TestReadRepairSet(t *testing.T) {
+	local := New(reflect.String, "a")
+	source := New(reflect.String, "a", "b")
+	r := NewReadRepair(local, source)
+
+	if ok, _ := r.Has("a", "b"); !ok {
+		t.Error("Has: items of the source should be found")
+	}
+	if ok, _ := local.Has("b"); !ok {
+		t.Error("Has: items found in the source should be back-filled")
+	}
+	if ok, _ := r.Has("c"); ok {
+		t.Error("Has: items missing from the source should not be found")
+	}
+
+	expected := RepairStats{Hits: 1, Misses: 2, Repairs: 1}
+	if st := r.Stats(); st != expected {
+		t.Errorf("Stats: expected %+v, got %+v", expected, st)
+	}
+
+	source.Add("d")
+	r.SetRepair(false)
+	if ok, _ := r.Has("d"); ok {
+		t.Error("Has: the source should not be consulted when repair is disabled")
+	}
+
+	if _, err := r.Has(1); err == nil {
+		t.Error("Has: items of another kind should return an error")
+	}
+	if _, err := NewReadRepair(local, failingSource{}).Has("e"); err == nil {
+		t.Error("Has: errors of the source should be returned")
+	}
+}
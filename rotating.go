@@ -0,0 +1,185 @@
+package goset
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// RotatingSet is an exact dedup set whose items expire in bulk. It keeps a
+// fixed number of time slices, each covering one period: items are added to
+// the current slice, and every period the oldest slice is dropped as a whole,
+// in O(1). An item is therefore remembered for at least (slices-1)*period and
+// at most slices*period after it was last added.
+//
+// Rotation happens lazily when the set is accessed, no goroutine is involved.
+// A RotatingSet is safe for concurrent use.
+type RotatingSet struct {
+	kind   reflect.Kind
+	period time.Duration
+
+	l      sync.Mutex
+	slices []map[interface{}]struct{} // the current slice first
+	start  time.Time                  // when the current slice started
+}
+
+// NewRotatingSet creates a RotatingSet of the given kind with n slices of the
+// given period. It returns an error if n or period is not positive.
+func NewRotatingSet(kind reflect.Kind, period time.Duration, n int) (*RotatingSet, error) {
+	if period <= 0 {
+		return nil, fmt.Errorf("rotation period must be positive, got %s", period)
+	}
+	if n <= 0 {
+		return nil, fmt.Errorf("number of slices must be positive, got %d", n)
+	}
+
+	r := &RotatingSet{
+		kind:   kind,
+		period: period,
+		slices: make([]map[interface{}]struct{}, n),
+		start:  now(),
+	}
+	for i := range r.slices {
+		r.slices[i] = make(map[interface{}]struct{})
+	}
+	return r, nil
+}
+
+// Kind returns the kind of the items the set holds.
+func (r *RotatingSet) Kind() reflect.Kind {
+	return r.kind
+}
+
+// Add includes the specified items in the current slice, refreshing the ones
+// that are in an older slice already.
+func (r *RotatingSet) Add(items ...interface{}) error {
+	_, err := r.AddReport(items...)
+	return err
+}
+
+// AddReport is like Add, but also returns the number of items that were not
+// in the set yet, which makes it a one step dedup check.
+func (r *RotatingSet) AddReport(items ...interface{}) (added int, err error) {
+	if err := typecheck(r.kind, items...); err != nil {
+		return 0, err
+	}
+
+	r.l.Lock()
+	defer r.l.Unlock()
+	r.rotate()
+
+	for _, item := range items {
+		item = normalize(item)
+		if _, ok := r.slices[0][item]; ok {
+			continue
+		}
+
+		found := false
+		for _, slice := range r.slices[1:] {
+			if _, ok := slice[item]; ok {
+				delete(slice, item)
+				found = true
+				break
+			}
+		}
+		if !found {
+			added++
+		}
+		r.slices[0][item] = struct{}{}
+	}
+	return added, nil
+}
+
+// Has looks for the existence of items passed in any slice. It returns false
+// if nothing is passed. For multiple items it returns true only if all of the
+// items exist.
+func (r *RotatingSet) Has(items ...interface{}) (bool, error) {
+	if len(items) == 0 {
+		return false, nil
+	}
+	if err := typecheck(r.kind, items...); err != nil {
+		return false, err
+	}
+
+	r.l.Lock()
+	defer r.l.Unlock()
+	r.rotate()
+
+	for _, item := range items {
+		if !r.contains(normalize(item)) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Size returns the number of items of all slices.
+func (r *RotatingSet) Size() int {
+	r.l.Lock()
+	defer r.l.Unlock()
+	r.rotate()
+
+	n := 0
+	for _, slice := range r.slices {
+		n += len(slice)
+	}
+	return n
+}
+
+// List returns a slice of the items of all slices.
+func (r *RotatingSet) List() []interface{} {
+	r.l.Lock()
+	defer r.l.Unlock()
+	r.rotate()
+
+	list := make([]interface{}, 0)
+	for _, slice := range r.slices {
+		for item := range slice {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// Rotate drops the oldest slice and starts a new current slice immediately,
+// without waiting for the period to elapse.
+func (r *RotatingSet) Rotate() {
+	r.l.Lock()
+	defer r.l.Unlock()
+
+	r.shift(1)
+	r.start = now()
+}
+
+func (r *RotatingSet) contains(item interface{}) bool {
+	for _, slice := range r.slices {
+		if _, ok := slice[item]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// rotate drops the slices that expired since the current slice started. The
+// lock must be held.
+func (r *RotatingSet) rotate() {
+	n := int(now().Sub(r.start) / r.period)
+	if n <= 0 {
+		return
+	}
+	r.shift(n)
+	r.start = r.start.Add(time.Duration(n) * r.period)
+}
+
+// shift drops the n oldest slices, replacing them with empty ones at the
+// front. The lock must be held.
+func (r *RotatingSet) shift(n int) {
+	if n > len(r.slices) {
+		n = len(r.slices)
+	}
+	copy(r.slices[n:], r.slices[:len(r.slices)-n])
+	for i := 0; i < n; i++ {
+		r.slices[i] = make(map[interface{}]struct{})
+	}
+}
@@ -0,0 +1,62 @@
+package goset
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRotatingSet(t *testing.T) {
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := base
+	defer func() { now = time.Now }()
+	now = func() time.Time { return clock }
+
+	r, err := NewRotatingSet(reflect.String, time.Minute, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n, _ := r.AddReport("a", "b", "a"); n != 2 {
+		t.Errorf("AddReport: expected two new items, got %d", n)
+	}
+
+	clock = base.Add(90 * time.Second)
+	r.Add("c")
+	if n, _ := r.AddReport("b"); n != 0 {
+		t.Error("AddReport: items of older slices should not be new")
+	}
+
+	clock = base.Add(3 * time.Minute)
+	if ok, _ := r.Has("a"); ok {
+		t.Error("RotatingSet: items of the oldest slice should expire")
+	}
+	if ok, _ := r.Has("b", "c"); !ok || r.Size() != 2 {
+		t.Errorf("RotatingSet: refreshed and recent items should be kept, got %v", r.List())
+	}
+
+	r.Rotate()
+	r.Rotate()
+	if ok, _ := r.Has("b"); ok || r.Size() != 0 {
+		t.Errorf("Rotate: expected an empty set, got %v", r.List())
+	}
+
+	clock = base.Add(time.Hour)
+	r.Add("d")
+	if ok, _ := r.Has("d"); !ok || r.Size() != 1 {
+		t.Errorf("RotatingSet: expected [d] after a long pause, got %v", r.List())
+	}
+
+	if err := r.Add(1); err == nil {
+		t.Error("RotatingSet: items of another kind should return an error")
+	}
+}
+
+func TestNewRotatingSet_invalid(t *testing.T) {
+	if _, err := NewRotatingSet(reflect.String, 0, 3); err == nil {
+		t.Error("NewRotatingSet: a zero period should return an error")
+	}
+	if _, err := NewRotatingSet(reflect.String, time.Second, 0); err == nil {
+		t.Error("NewRotatingSet: zero slices should return an error")
+	}
+}
@@ -0,0 +1,87 @@
+package goset
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is returned by the methods of SafeSet when the underlying call
+// panicked.
+type PanicError struct {
+	Value interface{} // the value passed to panic
+	Stack []byte      // the stack of the panicking goroutine
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("goset: recovered from panic: %v\n%s", e.Value, e.Stack)
+}
+
+// SafeSet wraps a set so that any panic raised while it's used, because of
+// an unhashable item or a nil set for example, is returned as a *PanicError
+// instead of crashing the program. See Set.Safe.
+type SafeSet struct {
+	s *Set
+}
+
+// Safe returns a wrapper of s that converts panics into errors. It's meant
+// for services that feed user controlled input to a set.
+func (s *Set) Safe() SafeSet {
+	return SafeSet{s: s}
+}
+
+// Unwrap returns the wrapped set.
+func (ss SafeSet) Unwrap() *Set {
+	return ss.s
+}
+
+// Do calls fn with the wrapped set, and returns its error or the panic it
+// raised as a *PanicError.
+func (ss SafeSet) Do(fn func(s *Set) error) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = &PanicError{Value: v, Stack: debug.Stack()}
+		}
+	}()
+	return fn(ss.s)
+}
+
+// Add is like Set.Add.
+func (ss SafeSet) Add(items ...interface{}) error {
+	return ss.Do(func(s *Set) error {
+		return s.Add(items...)
+	})
+}
+
+// Remove is like Set.Remove.
+func (ss SafeSet) Remove(items ...interface{}) error {
+	return ss.Do(func(s *Set) error {
+		return s.Remove(items...)
+	})
+}
+
+// Has is like Set.Has.
+func (ss SafeSet) Has(items ...interface{}) (ok bool, err error) {
+	err = ss.Do(func(s *Set) error {
+		ok, err = s.Has(items...)
+		return err
+	})
+	return ok, err
+}
+
+// Size is like Set.Size.
+func (ss SafeSet) Size() (n int, err error) {
+	err = ss.Do(func(s *Set) error {
+		n = s.Size()
+		return nil
+	})
+	return n, err
+}
+
+// List is like Set.List.
+func (ss SafeSet) List() (list []interface{}, err error) {
+	err = ss.Do(func(s *Set) error {
+		list = s.List()
+		return nil
+	})
+	return list, err
+}
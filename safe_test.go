@@ -0,0 +1,41 @@
+package goset
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSet_Safe(t *testing.T) {
+	ss := New(reflect.String, "a").Safe()
+	if err := ss.Add("b"); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := ss.Has("a", "b"); !ok || err != nil {
+		t.Errorf("Safe: expected a and b, got %v, %v", ok, err)
+	}
+	if err := ss.Add(1); err == nil {
+		t.Error("Safe: errors of the set should be returned")
+	}
+
+	var nilSet *Set
+	_, err := nilSet.Safe().Size()
+	var perr *PanicError
+	if !errors.As(err, &perr) {
+		t.Fatalf("Safe: expected a *PanicError, got %v", err)
+	}
+	if !strings.Contains(perr.Error(), "goroutine") {
+		t.Error("Safe: the error should contain the stack")
+	}
+
+	err = ss.Do(func(s *Set) error {
+		panic("boom")
+	})
+	if !errors.As(err, &perr) || perr.Value != "boom" {
+		t.Errorf("Safe: expected the panic value, got %v", err)
+	}
+	if n, _ := ss.Size(); n != 2 {
+		t.Error("Safe: the set should stay usable after a panic")
+	}
+}
@@ -0,0 +1,13 @@
+package goset
+
+// scheduleHook, if set, is called by set operations right before they acquire
+// the lock of the set, with the name of the operation. It's an injection point
+// for tests, which use it to force specific interleavings of concurrent calls.
+// It must only be changed while no set is in use.
+var scheduleHook func(op string)
+
+func schedule(op string) {
+	if scheduleHook != nil {
+		scheduleHook(op)
+	}
+}
@@ -0,0 +1,187 @@
+package goset
+
+import (
+	"bytes"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// step is a single scheduling decision: actor is allowed to run op.
+type step struct {
+	actor string
+	op    string
+}
+
+// replay forces concurrent actors to pass the schedule points of the set
+// operations in a fixed order. Operations of actors that have no pending step
+// left, and of goroutines that aren't actors, are not held back.
+type replay struct {
+	t     *testing.T
+	steps []step
+
+	mu     sync.Mutex
+	cond   *sync.Cond
+	pos    int
+	actors map[string]string // goroutine id -> actor
+	wg     sync.WaitGroup
+}
+
+// newReplay installs a replay of steps as the schedule hook of the package. The
+// hook is removed when the test finishes.
+func newReplay(t *testing.T, steps ...step) *replay {
+	r := &replay{t: t, steps: steps, actors: make(map[string]string)}
+	r.cond = sync.NewCond(&r.mu)
+
+	scheduleHook = r.hook
+	t.Cleanup(func() { scheduleHook = nil })
+	return r
+}
+
+// run starts fn in a new goroutine acting as actor.
+func (r *replay) run(actor string, fn func()) {
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+
+		r.mu.Lock()
+		r.actors[goid()] = actor
+		r.mu.Unlock()
+
+		fn()
+	}()
+}
+
+// wait waits for all actors to finish and fails the test if they don't finish
+// in time, which means the steps can't be replayed.
+func (r *replay) wait() {
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.t.Fatalf("replay: stuck at step %d of %v", r.pos, r.steps)
+	}
+}
+
+func (r *replay) hook(op string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	actor, ok := r.actors[goid()]
+	if !ok || !r.pending(actor) {
+		return
+	}
+
+	for r.steps[r.pos] != (step{actor, op}) {
+		r.cond.Wait()
+	}
+	r.pos++
+	r.cond.Broadcast()
+}
+
+// pending reports whether actor has steps left. r.mu must be held.
+func (r *replay) pending(actor string) bool {
+	for _, st := range r.steps[r.pos:] {
+		if st.actor == actor {
+			return true
+		}
+	}
+	return false
+}
+
+// goid returns the id of the calling goroutine.
+func goid() string {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	return strings.Fields(string(buf[:bytes.IndexByte(buf, '[')]))[1]
+}
+
+// TestReplay_hasThenAdd reproduces the classic check-then-act race: both actors
+// see the item missing and both believe they inserted it.
+func TestReplay_hasThenAdd(t *testing.T) {
+	s := New(reflect.String)
+
+	r := newReplay(t,
+		step{"a", "Has"},
+		step{"b", "Has"},
+		step{"a", "Add"},
+		step{"b", "Add"},
+	)
+
+	var mu sync.Mutex
+	inserted := 0
+	for _, actor := range []string{"a", "b"} {
+		r.run(actor, func() {
+			if ok, _ := s.Has("x"); !ok {
+				s.Add("x")
+				mu.Lock()
+				inserted++
+				mu.Unlock()
+			}
+		})
+	}
+	r.wait()
+
+	if inserted != 2 || s.Size() != 1 {
+		t.Errorf("replay: expected both actors to insert the same item, got %d inserts", inserted)
+	}
+}
+
+// TestReplay_serialized replays the same actors without interleaving.
+func TestReplay_serialized(t *testing.T) {
+	s := New(reflect.String)
+
+	r := newReplay(t,
+		step{"a", "Has"},
+		step{"a", "Add"},
+		step{"b", "Has"},
+	)
+
+	var mu sync.Mutex
+	inserted := 0
+	for _, actor := range []string{"a", "b"} {
+		r.run(actor, func() {
+			if ok, _ := s.Has("x"); !ok {
+				s.Add("x")
+				mu.Lock()
+				inserted++
+				mu.Unlock()
+			}
+		})
+	}
+	r.wait()
+
+	if inserted != 1 {
+		t.Errorf("replay: expected a single insert, got %d", inserted)
+	}
+}
+
+// TestReplay_removeDuringList checks that List observes a removal scheduled
+// before it.
+func TestReplay_removeDuringList(t *testing.T) {
+	s := New(reflect.Int, 1, 2, 3)
+
+	r := newReplay(t,
+		step{"writer", "Remove"},
+		step{"reader", "List"},
+	)
+
+	var list []interface{}
+	r.run("reader", func() { list = s.List() })
+	r.run("writer", func() { s.Remove(2) })
+	r.wait()
+
+	if len(list) != 2 {
+		t.Errorf("replay: List should see the removal, got %v", list)
+	}
+}
@@ -0,0 +1,197 @@
+package goset
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// ScoredItem is an item of a ScoredSet with its score.
+type ScoredItem struct {
+	Item  interface{}
+	Score float64
+}
+
+// ScorePolicy decides the score of an item present in both sets of a
+// ScoredSet union.
+type ScorePolicy int
+
+const (
+	// ScoreSum adds the scores of both sets.
+	ScoreSum ScorePolicy = iota
+
+	// ScoreMax keeps the highest score.
+	ScoreMax
+
+	// ScoreMin keeps the lowest score.
+	ScoreMin
+)
+
+// ScoredSet is a set whose items each carry a float64 score, like a sorted
+// set of Redis. It's safe for concurrent use.
+type ScoredSet struct {
+	kind reflect.Kind
+	l    sync.RWMutex
+	m    map[interface{}]float64
+}
+
+// NewScored creates a new, empty ScoredSet of the given kind.
+func NewScored(kind reflect.Kind) *ScoredSet {
+	return &ScoredSet{kind: kind, m: make(map[interface{}]float64)}
+}
+
+// Kind returns the kind of the items the set holds.
+func (s *ScoredSet) Kind() reflect.Kind {
+	return s.kind
+}
+
+// AddWithScore includes item in the set with the given score, replacing its
+// score if it's already a member.
+func (s *ScoredSet) AddWithScore(item interface{}, score float64) error {
+	if err := typecheck(s.kind, item); err != nil {
+		return err
+	}
+
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	s.m[normalize(item)] = score
+	return nil
+}
+
+// Remove deletes the specified items from the set.
+func (s *ScoredSet) Remove(items ...interface{}) error {
+	if err := typecheck(s.kind, items...); err != nil {
+		return err
+	}
+
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	for _, item := range items {
+		delete(s.m, normalize(item))
+	}
+	return nil
+}
+
+// Score returns the score of item. The returned bool is false if item is not
+// a member.
+func (s *ScoredSet) Score(item interface{}) (float64, bool) {
+	s.l.RLock()
+	defer s.l.RUnlock()
+
+	score, ok := s.m[normalize(item)]
+	return score, ok
+}
+
+// Has looks for the existence of items passed. It returns false if nothing is
+// passed. For multiple items it returns true only if all of the items exist.
+func (s *ScoredSet) Has(items ...interface{}) (bool, error) {
+	if len(items) == 0 {
+		return false, nil
+	}
+	if err := typecheck(s.kind, items...); err != nil {
+		return false, err
+	}
+
+	s.l.RLock()
+	defer s.l.RUnlock()
+
+	for _, item := range items {
+		if _, ok := s.m[normalize(item)]; !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Size returns the number of items in the set.
+func (s *ScoredSet) Size() int {
+	s.l.RLock()
+	defer s.l.RUnlock()
+
+	return len(s.m)
+}
+
+// TopN returns the n items with the highest scores, highest first. Items with
+// equal scores are ordered by value. It returns all items if there are fewer
+// than n.
+func (s *ScoredSet) TopN(n int) []ScoredItem {
+	items := s.sorted()
+	slices.Reverse(items)
+	if n < len(items) {
+		items = items[:max(n, 0)]
+	}
+	return items
+}
+
+// RangeByScore returns the items whose score is between min and max, both
+// included, in ascending order of score.
+func (s *ScoredSet) RangeByScore(min, max float64) []ScoredItem {
+	items := s.sorted()
+	lo, _ := slices.BinarySearchFunc(items, min, func(it ScoredItem, score float64) int {
+		if it.Score < score {
+			return -1
+		}
+		return 1
+	})
+	hi, _ := slices.BinarySearchFunc(items, max, func(it ScoredItem, score float64) int {
+		if it.Score <= score {
+			return -1
+		}
+		return 1
+	})
+	if hi < lo {
+		return []ScoredItem{}
+	}
+	return items[lo:hi]
+}
+
+// Union returns a new ScoredSet with the items of s and t combined. The score
+// of an item present in both is decided by policy.
+func (s *ScoredSet) Union(t *ScoredSet, policy ScorePolicy) (*ScoredSet, error) {
+	if s.kind != t.kind {
+		return nil, fmt.Errorf("cannot perform the requested operation on mismatched sets; '%s' != '%s'", s.kind.String(), t.kind.String())
+	}
+
+	u := NewScored(s.kind)
+	for _, it := range s.sorted() {
+		u.m[it.Item] = it.Score
+	}
+	for _, it := range t.sorted() {
+		score, ok := u.m[it.Item]
+		switch {
+		case !ok:
+			score = it.Score
+		case policy == ScoreSum:
+			score += it.Score
+		case policy == ScoreMax:
+			score = max(score, it.Score)
+		case policy == ScoreMin:
+			score = min(score, it.Score)
+		}
+		u.m[it.Item] = score
+	}
+	return u, nil
+}
+
+// sorted returns the items of s in ascending order of score, and of value for
+// equal scores.
+func (s *ScoredSet) sorted() []ScoredItem {
+	s.l.RLock()
+	items := make([]ScoredItem, 0, len(s.m))
+	for item, score := range s.m {
+		items = append(items, ScoredItem{Item: item, Score: score})
+	}
+	s.l.RUnlock()
+
+	slices.SortFunc(items, func(a, b ScoredItem) int {
+		if c := cmp.Compare(a.Score, b.Score); c != 0 {
+			return c
+		}
+		return compare(a.Item, b.Item)
+	})
+	return items
+}
@@ -0,0 +1,70 @@
+package goset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScoredSet(t *testing.T) {
+	s := NewScored(reflect.String)
+	s.AddWithScore("a", 1)
+	s.AddWithScore("b", 3)
+	s.AddWithScore("c", 2)
+	s.AddWithScore("d", 3)
+	s.AddWithScore("a", 5)
+
+	if score, ok := s.Score("a"); !ok || score != 5 {
+		t.Errorf("Score: expected 5, got %v", score)
+	}
+	if _, ok := s.Score("x"); ok {
+		t.Error("Score: non members should not have a score")
+	}
+
+	expected := []ScoredItem{{"a", 5}, {"d", 3}}
+	if top := s.TopN(2); !reflect.DeepEqual(top, expected) {
+		t.Errorf("TopN: expected %v, got %v", expected, top)
+	}
+	if len(s.TopN(10)) != 4 || len(s.TopN(-1)) != 0 {
+		t.Error("TopN: unexpected number of items")
+	}
+
+	expected = []ScoredItem{{"c", 2}, {"b", 3}, {"d", 3}}
+	if items := s.RangeByScore(2, 3); !reflect.DeepEqual(items, expected) {
+		t.Errorf("RangeByScore: expected %v, got %v", expected, items)
+	}
+	if items := s.RangeByScore(3.5, 4); len(items) != 0 {
+		t.Errorf("RangeByScore: expected no items, got %v", items)
+	}
+
+	s.Remove("a")
+	if ok, _ := s.Has("a"); ok || s.Size() != 3 {
+		t.Error("Remove: the item should be removed")
+	}
+
+	if err := s.AddWithScore(1, 1); err == nil {
+		t.Error("AddWithScore: items of another kind should return an error")
+	}
+}
+
+func TestScoredSet_Union(t *testing.T) {
+	a := NewScored(reflect.String)
+	a.AddWithScore("x", 1)
+	a.AddWithScore("y", 4)
+	b := NewScored(reflect.String)
+	b.AddWithScore("y", 2)
+	b.AddWithScore("z", 3)
+
+	for policy, expected := range map[ScorePolicy]float64{ScoreSum: 6, ScoreMax: 4, ScoreMin: 2} {
+		u, err := a.Union(b, policy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if score, _ := u.Score("y"); score != expected || u.Size() != 3 {
+			t.Errorf("Union: expected a score of %v with policy %d, got %v", expected, policy, score)
+		}
+	}
+
+	if _, err := a.Union(NewScored(reflect.Int), ScoreSum); err == nil {
+		t.Error("Union: sets of mismatched kinds should return an error")
+	}
+}
@@ -5,45 +5,94 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
-	"sync"
 )
 
 type Set struct {
 	m    map[interface{}]struct{}
-	l    sync.RWMutex // we name it because we don't want to expose it
+	l    setLock      // we name it because we don't want to expose it
 	kind reflect.Kind // runtime generics enforcement
+	name string
+
+	// ids and items map members to stable IDs, see WithIDs. Both are nil
+	// unless the mode is enabled.
+	ids   map[interface{}]int
+	items []interface{}
+
+	pool *InternPool // see WithInternPool
+
+	removedCount int    // number of items ever removed, see Advise
+	version      uint64 // number of changes, see Version
+
+	// shared is true if m is referenced by a FrozenSet and must be copied
+	// before it's modified.
+	shared bool
+
+	// observers registered with OnAdd and OnRemove, and the items added and
+	// removed under the current write lock to notify them about.
+	observers []*observer
+	added     []interface{}
+	removed   []interface{}
+	cleared   bool
+
+	metrics *metrics // see WithMetrics
+
+	lazy *lazy // see NewLazy
+
+	journal *journal // see WithJournal
+
+	wal *wal // see NewWAL
+
+	retainCapacity bool // see WithRetainCapacity
+	sortedOutput   bool // see WithSortedOutput
+
+	flagSep     string // see WithFlagSeparator, a comma if empty
+	flagNoSplit bool   // true if values passed to Set are never split
 }
 
 // New creates and initialize a new Set. It's accept a variable number of
 // arguments to populate the initial set. If nothing passed a Set with zero
 // size is created.
 func New(kind reflect.Kind, items ...interface{}) *Set {
-	s := &Set{
-		kind: kind,
-		m: make(map[interface{}]struct{}), // struct{} doesn't take up space
-	}
+	return NewWithOptions(kind, WithItems(items...))
+}
 
-	s.Add(items...)
-	return s
+// Name returns the name given to the set with the WithName option.
+func (s *Set) Name() string {
+	return s.name
+}
+
+// Kind returns the kind of the items the set holds.
+func (s *Set) Kind() reflect.Kind {
+	return s.kind
 }
 
 // Add includes the specified items (one or more) to the set. If passed nothing
 // it silently returns.
 func (s *Set) Add(items ...interface{}) error {
+	_, err := s.AddReport(items...)
+	return err
+}
+
+// AddReport is like Add, but also returns the number of items that were not
+// in the set yet. An item passed more than once is counted once.
+func (s *Set) AddReport(items ...interface{}) (added int, err error) {
 	if len(items) == 0 {
-		return nil
+		return 0, nil
 	}
-	if err := s.typecheck(items); err != nil {
-		return err
+	if err := s.typecheck(items...); err != nil {
+		return 0, err
 	}
 
-	s.l.Lock()
-	defer s.l.Unlock()
+	schedule("Add")
+	s.lock()
+	defer s.unlock()
 
 	for _, item := range items {
-		s.m[item] = struct{}{}
+		if s.insert(item) {
+			added++
+		}
 	}
-	return nil
+	return added, s.flushWAL()
 }
 
 // Remove deletes the specified items from the set. If passed nothing it
@@ -52,17 +101,70 @@ func (s *Set) Remove(items ...interface{}) error {
 	if len(items) == 0 {
 		return nil
 	}
-	if err := s.typecheck(items); err != nil {
+	if err := s.typecheck(items...); err != nil {
 		return err
 	}
 
-	s.l.Lock()
-	defer s.l.Unlock()
+	schedule("Remove")
+	s.lock()
+	defer s.unlock()
 
 	for _, item := range items {
-		delete(s.m, item)
+		s.delete(item)
 	}
-	return nil
+	return s.flushWAL()
+}
+
+// RemoveReport deletes item from the set and reports whether it existed. Both
+// happen atomically, under a single write lock.
+func (s *Set) RemoveReport(item interface{}) (existed bool, err error) {
+	if err := s.typecheck(item); err != nil {
+		return false, err
+	}
+
+	schedule("Remove")
+	s.lock()
+	defer s.unlock()
+
+	existed = s.delete(item)
+	return existed, s.flushWAL()
+}
+
+// GetOrAdd adds item to the set if it's not a member yet, and reports
+// whether it existed already. Both happen atomically, under a single write
+// lock, unlike a Has followed by an Add.
+func (s *Set) GetOrAdd(item interface{}) (existing bool, err error) {
+	if err := s.typecheck(item); err != nil {
+		return false, err
+	}
+
+	schedule("Add")
+	s.lock()
+	defer s.unlock()
+
+	existing = !s.insert(item)
+	return existing, s.flushWAL()
+}
+
+// CompareAndSwap replaces old with new if old is a member of the set and new
+// is not, and reports whether it did. Both happen atomically, under a single
+// write lock, which makes it suited to moving an item between states, such as
+// "pending" to "done".
+func (s *Set) CompareAndSwap(old, new interface{}) (swapped bool, err error) {
+	if err := s.typecheck(old, new); err != nil {
+		return false, err
+	}
+
+	schedule("Add")
+	s.lock()
+	defer s.unlock()
+
+	if !s.contains(old) || s.contains(new) {
+		return false, nil
+	}
+	s.delete(old)
+	s.insert(new)
+	return true, s.flushWAL()
 }
 
 // Has looks for the existence of items passed. It returns false if nothing is
@@ -72,33 +174,116 @@ func (s *Set) Has(items ...interface{}) (bool, error) {
 	if len(items) == 0 {
 		return false, nil
 	}
-	if err := s.typecheck(items); err != nil {
+	if err := s.typecheck(items...); err != nil {
 		return false, err
 	}
 
-	s.l.RLock()
+	schedule("Has")
+	s.rlock()
 	defer s.l.RUnlock()
 
 	for _, item := range items {
-		if _, ok := s.m[item]; !ok {
+		if !s.contains(item) {
 			return false, nil
 		}
 	}
 	return true, nil
 }
 
+// ContainsAll is an alias for Has. It reports whether all of the items passed
+// exist, checking them under a single read lock and stopping at the first
+// missing one.
+func (s *Set) ContainsAll(items ...interface{}) (bool, error) {
+	return s.Has(items...)
+}
+
+// ContainsNone reports whether none of the items passed exist. It checks them
+// under a single read lock and stops at the first item found. It returns true
+// if nothing is passed.
+func (s *Set) ContainsNone(items ...interface{}) (bool, error) {
+	if len(items) == 0 {
+		return true, nil
+	}
+	if err := s.typecheck(items...); err != nil {
+		return false, err
+	}
+
+	schedule("Has")
+	s.rlock()
+	defer s.l.RUnlock()
+
+	for _, item := range items {
+		if s.contains(item) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// HasEach reports the membership of every item passed, in the same order. All
+// items are looked up under a single read lock.
+func (s *Set) HasEach(items ...interface{}) ([]bool, error) {
+	if err := s.typecheck(items...); err != nil {
+		return nil, err
+	}
+
+	found := make([]bool, len(items))
+
+	schedule("HasEach")
+	s.rlock()
+	defer s.l.RUnlock()
+
+	for i, item := range items {
+		found[i] = s.contains(item)
+	}
+	return found, nil
+}
+
 // Size returns the number of items in a set.
 func (s *Set) Size() int {
-	s.l.RLock()
+	s.rlock()
 	defer s.l.RUnlock()
 	return len(s.m)
 }
 
 // Clear removes all items from the set.
 func (s *Set) Clear() {
-	s.l.Lock()
-	defer s.l.Unlock()
-	s.m = make(map[interface{}]struct{})
+	schedule("Clear")
+	s.lock()
+	defer s.unlock()
+
+	if s.pool != nil {
+		for item := range s.m {
+			s.pool.release(item)
+		}
+	}
+	if len(s.observers) > 0 && len(s.m) > 0 {
+		for item := range s.m {
+			s.removed = append(s.removed, item)
+		}
+		s.cleared = true
+	}
+	if s.journal != nil {
+		for item := range s.m {
+			s.journal.record(item, false)
+		}
+	}
+	if s.wal != nil {
+		for item := range s.m {
+			s.wal.record(item, false)
+		}
+	}
+	s.removedCount += len(s.m)
+	s.version += uint64(len(s.m))
+	if s.metrics != nil {
+		s.metrics.removes.Add(int64(len(s.m)))
+	}
+	if s.retainCapacity && !s.shared {
+		clear(s.m)
+	} else {
+		s.m = make(map[interface{}]struct{})
+	}
+	s.shared = false
 }
 
 // IsEmpty checks for emptiness of the set.
@@ -107,28 +292,36 @@ func (s *Set) IsEmpty() bool {
 }
 
 // IsEqual test whether s and t are the same in size and have the same items.
-func (s *Set) IsEqual(t *Set) (bool, error) {
+func (s *Set) IsEqual(t Interface) (bool, error) {
 	if err := s.typematch(t); err != nil {
 		return false, err
 	}
 
-	if s.Size() != t.Size() {
+	o := asSet(t)
+	defer rlockAll([]*Set{s, o})()
+
+	if len(s.m) != len(o.m) {
 		return false, nil
 	}
-	if u, _ := s.Union(t); s.Size() != u.Size() {
-		return false, nil
+	for item := range s.m {
+		if !o.contains(item) {
+			return false, nil
+		}
 	}
 	return true, nil
 }
 
 // IsSubset tests t is a subset of s.
-func (s *Set) IsSubset(t *Set) (bool, error) {
+func (s *Set) IsSubset(t Interface) (bool, error) {
 	if err := s.typematch(t); err != nil {
 		return false, err
 	}
 
-	for _, item := range t.List() {
-		if ok, _ := s.Has(item); !ok {
+	o := asSet(t)
+	defer rlockAll([]*Set{s, o})()
+
+	for item := range o.m {
+		if !s.contains(item) {
 			return false, nil
 		}
 	}
@@ -136,14 +329,53 @@ func (s *Set) IsSubset(t *Set) (bool, error) {
 }
 
 // IsSuperset tests if t is a superset of s.
-func (s *Set) IsSuperset(t *Set) (bool, error) {
-	return t.IsSubset(s)
+func (s *Set) IsSuperset(t Interface) (bool, error) {
+	if err := s.typematch(t); err != nil {
+		return false, err
+	}
+	return asSet(t).IsSubset(s)
 }
 
-// String representation of s
+// IsDisjoint tests whether s and t have no items in common. It iterates over
+// the smaller of the two sets and stops at the first shared item.
+func (s *Set) IsDisjoint(t Interface) (bool, error) {
+	if err := s.typematch(t); err != nil {
+		return false, err
+	}
+
+	small, large := s, asSet(t)
+	defer rlockAll([]*Set{small, large})()
+
+	if len(small.m) > len(large.m) {
+		small, large = large, small
+	}
+
+	for item := range small.m {
+		if large.contains(item) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// String representation of s. The items are in sorted order if s was created
+// with WithSortedOutput, in no particular order otherwise.
 func (s *Set) String() string {
-	t := make([]string, 0)
-	for _, item := range s.List() {
+	if s.sortedOutput {
+		return s.SortedString()
+	}
+	return formatList(s.List())
+}
+
+// SortedString is like String, but the items are always in sorted order, so
+// that equal sets print identically.
+func (s *Set) SortedString() string {
+	return formatList(s.sortedList())
+}
+
+func formatList(list []interface{}) string {
+	t := make([]string, 0, len(list))
+	for _, item := range list {
 		t = append(t, fmt.Sprintf("%v", item))
 	}
 	return fmt.Sprintf("[%s]", strings.Join(t, ", "))
@@ -151,7 +383,8 @@ func (s *Set) String() string {
 
 // List returns a slice of all items
 func (s *Set) List() []interface{} {
-	s.l.RLock()
+	schedule("List")
+	s.rlock()
 	defer s.l.RUnlock()
 	list := make([]interface{}, 0)
 	for item := range s.m {
@@ -167,90 +400,182 @@ func (s *Set) Copy() *Set {
 
 // Union is the merger of two sets. It returns a new set with the element in s
 // and t combined.
-func (s *Set) Union(t *Set) (*Set, error) {
+func (s *Set) Union(t Interface) (*Set, error) {
 	if err := s.typematch(t); err != nil {
 		return nil, err
 	}
 
-	u := New(s.kind, t.List()...)
-	for _, item := range s.List() {
-		u.Add(item)
-	}
-	return u, nil
+	return Union(s, asSet(t))
 }
 
 // Merge is like Union, however it modifies the current set it's applied on
 // with the given t set.
-func (s *Set) Merge(t *Set) error {
+//
+// Like all operations on two sets, Merge holds the locks of both sets for its
+// whole duration, so it's atomic with respect to concurrent changes of either.
+// The locks are acquired in a fixed global order, so s.Merge(t) and t.Merge(s)
+// may safely run concurrently.
+func (s *Set) Merge(t Interface) error {
 	if err := s.typematch(t); err != nil {
 		return err
 	}
 
-	for _, item := range t.List() {
-		s.Add(item)
+	o := asSet(t)
+
+	schedule("Merge")
+	defer lockWith(s, o)()
+
+	for item := range o.m {
+		s.insert(item)
 	}
-	return nil
+	return s.flushWAL()
 }
 
 // Separate removes the set items containing in t from set s. Please aware that
 // it's not the opposite of Merge.
-func (s *Set) Separate(t *Set) error {
+func (s *Set) Separate(t Interface) error {
 	if err := s.typematch(t); err != nil {
 		return err
 	}
 
-	for _, item := range t.List() {
-		s.Remove(item)
+	o := asSet(t)
+
+	schedule("Separate")
+	defer lockWith(s, o)()
+
+	for item := range o.m {
+		s.delete(item)
 	}
-	return nil
+	return s.flushWAL()
 }
 
-// Intersection returns a new set which contains items which is in both s and t.
-func (s *Set) Intersection(t *Set) (*Set, error) {
+// RetainAll removes the items from s that are not in t, so that s only keeps
+// the items both sets have in common. It's the in-place variant of
+// Intersection.
+func (s *Set) RetainAll(t Interface) error {
 	if err := s.typematch(t); err != nil {
-		return nil, err
+		return err
 	}
 
-	u := New(s.kind)
-	for _, item := range s.List() {
-		if ok, _ := t.Has(item); ok {
-			u.Add(item)
+	o := asSet(t)
+
+	schedule("RetainAll")
+	defer lockWith(s, o)()
+
+	for item := range s.m {
+		if !o.contains(item) {
+			s.delete(item)
 		}
 	}
-	for _, item := range t.List() {
-		if ok, _ := s.Has(item); ok {
-			u.Add(item)
-		}
+	return s.flushWAL()
+}
+
+// Intersection returns a new set which contains items which is in both s and t.
+func (s *Set) Intersection(t Interface) (*Set, error) {
+	if err := s.typematch(t); err != nil {
+		return nil, err
 	}
-	return u, nil
+
+	return Intersection(s, asSet(t))
 }
 
 // Intersection returns a new set which contains items which are both s but not in t.
-func (s *Set) Difference(t *Set) (*Set, error) {
+func (s *Set) Difference(t Interface) (*Set, error) {
 	if err := s.typematch(t); err != nil {
 		return nil, err
 	}
 
+	return Difference(s, asSet(t))
+}
+
+// Symmetric returns a new set which s is the difference of items  which are in
+// one of either, but not in both.
+func (s *Set) SymmetricDifference(t Interface) (*Set, error) {
+	if err := s.typematch(t); err != nil {
+		return nil, err
+	}
+
+	o := asSet(t)
+	defer rlockAll([]*Set{s, o})()
+
 	u := New(s.kind)
-	for _, item := range s.List() {
-		if ok, _ := t.Has(item); !ok {
-			u.Add(item)
+	for item := range s.m {
+		if !o.contains(item) {
+			u.insert(item)
+		}
+	}
+	for item := range o.m {
+		if !s.contains(item) {
+			u.insert(item)
 		}
 	}
 	return u, nil
 }
 
-// Symmetric returns a new set which s is the difference of items  which are in
-// one of either, but not in both.
-func (s *Set) SymmetricDifference(t *Set) (*Set, error) {
+// SymmetricDifferenceUpdate modifies s so that it contains the items which are
+// in one of either s or t, but not in both. It's the in-place variant of
+// SymmetricDifference.
+func (s *Set) SymmetricDifferenceUpdate(t Interface) error {
 	if err := s.typematch(t); err != nil {
-		return nil, err
+		return err
 	}
 
-	u, _ := s.Difference(t)
-	v, _ := t.Difference(s)
-	res, _ := u.Union(v)
-	return res, nil
+	o := asSet(t)
+
+	schedule("SymmetricDifferenceUpdate")
+	defer lockWith(s, o)()
+
+	for item := range o.m {
+		if _, ok := s.m[item]; ok {
+			s.delete(item)
+		} else {
+			s.insert(item)
+		}
+	}
+	return s.flushWAL()
+}
+
+// AppendTo appends all items of s to dst and returns the extended slice. Unlike
+// List it doesn't allocate if dst has enough capacity, so hot paths can reuse
+// a buffer:
+//
+//	buf = s.AppendTo(buf[:0])
+func (s *Set) AppendTo(dst []interface{}) []interface{} {
+	s.rlock()
+	defer s.l.RUnlock()
+
+	for item := range s.m {
+		dst = append(dst, item)
+	}
+	return dst
+}
+
+// AppendStrings is like AppendTo for the items of type string. Items of other
+// types are skipped.
+func (s *Set) AppendStrings(dst []string) []string {
+	s.rlock()
+	defer s.l.RUnlock()
+
+	for item := range s.m {
+		if v, ok := item.(string); ok {
+			dst = append(dst, v)
+		}
+	}
+	return dst
+}
+
+// AppendInts is like AppendTo for the items of type int. Items of other types
+// are skipped.
+func (s *Set) AppendInts(dst []int) []int {
+	s.rlock()
+	defer s.l.RUnlock()
+
+	for item := range s.m {
+		if v, ok := item.(int); ok {
+			dst = append(dst, v)
+		}
+	}
+	return dst
 }
 
 // StringSlice is a helper function that returns a slice of strings of s. If
@@ -283,18 +608,249 @@ func (s *Set) IntSlice() []int {
 	return slice
 }
 
-func (s *Set) typematch(t *Set) error {
-	if s.kind != t.kind {
-		return fmt.Errorf("cannot perform the requested operation on mismatched sets; '%s' != '%s'", s.kind.String(), t.kind.String())
+// Int64Slice is a helper function that returns a slice of int64s of s. Items
+// of other types are skipped.
+func (s *Set) Int64Slice() []int64 {
+	return SliceOfSkip[int64](s)
+}
+
+// UintSlice is a helper function that returns a slice of uints of s. Items of
+// other types are skipped.
+func (s *Set) UintSlice() []uint {
+	return SliceOfSkip[uint](s)
+}
+
+// Float64Slice is a helper function that returns a slice of float64s of s.
+// Items of other types are skipped.
+func (s *Set) Float64Slice() []float64 {
+	return SliceOfSkip[float64](s)
+}
+
+// BoolSlice is a helper function that returns a slice of bools of s. Items of
+// other types are skipped.
+func (s *Set) BoolSlice() []bool {
+	return SliceOfSkip[bool](s)
+}
+
+// Any reports whether pred returns true for at least one item of s. It stops
+// at the first match. An empty set returns false.
+func (s *Set) Any(pred func(item interface{}) bool) bool {
+	s.rlock()
+	defer s.l.RUnlock()
+
+	for item := range s.m {
+		if pred(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// All reports whether pred returns true for every item of s. It stops at the
+// first item that doesn't match. An empty set returns true.
+func (s *Set) All(pred func(item interface{}) bool) bool {
+	s.rlock()
+	defer s.l.RUnlock()
+
+	for item := range s.m {
+		if !pred(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// None reports whether pred returns false for every item of s. It stops at
+// the first match. An empty set returns true.
+func (s *Set) None(pred func(item interface{}) bool) bool {
+	return !s.Any(pred)
+}
+
+// Find returns an item of s for which pred returns true, stopping at the first
+// match. The returned bool is false if no item matches. Which of several
+// matching items is returned is unspecified.
+func (s *Set) Find(pred func(item interface{}) bool) (interface{}, bool) {
+	s.rlock()
+	defer s.l.RUnlock()
+
+	for item := range s.m {
+		if pred(item) {
+			return item, true
+		}
+	}
+	return nil, false
+}
+
+// CountFunc returns the number of items of s for which pred returns true,
+// evaluated under a single read lock.
+func (s *Set) CountFunc(pred func(item interface{}) bool) int {
+	s.rlock()
+	defer s.l.RUnlock()
+
+	n := 0
+	for item := range s.m {
+		if pred(item) {
+			n++
+		}
+	}
+	return n
+}
+
+// RemoveIf removes the items of s for which pred returns true, in a single
+// pass under the write lock, and returns how many were removed. pred must not
+// use s.
+func (s *Set) RemoveIf(pred func(item interface{}) bool) int {
+	schedule("Remove")
+	s.lock()
+	defer s.unlock()
+
+	removed := 0
+	for item := range s.m {
+		if pred(item) && s.delete(item) {
+			removed++
+		}
+	}
+	return removed
+}
+
+// Partition splits s into two new sets in a single pass. The first set contains
+// the items for which pred returns true, the second one all other items.
+func (s *Set) Partition(pred func(item interface{}) bool) (matching, rest *Set) {
+	matching, rest = New(s.kind), New(s.kind)
+
+	s.rlock()
+	defer s.l.RUnlock()
+
+	for item := range s.m {
+		if pred(item) {
+			matching.m[item] = struct{}{}
+		} else {
+			rest.m[item] = struct{}{}
+		}
+	}
+	return matching, rest
+}
+
+// GroupBy buckets the items of s into new sets keyed by the result of keyFn.
+// Every item ends up in exactly one of the returned sets. The keys must be
+// hashable.
+func (s *Set) GroupBy(keyFn func(item interface{}) interface{}) map[interface{}]*Set {
+	groups := make(map[interface{}]*Set)
+
+	s.rlock()
+	defer s.l.RUnlock()
+
+	for item := range s.m {
+		key := keyFn(item)
+		g, ok := groups[key]
+		if !ok {
+			g = New(s.kind)
+			groups[key] = g
+		}
+		g.m[item] = struct{}{}
+	}
+	return groups
+}
+
+// insert adds item to s and reports whether it was new. The write lock must be
+// held.
+func (s *Set) insert(item interface{}) bool {
+	item = normalize(item)
+	if _, ok := s.m[item]; ok {
+		return false
+	}
+	if s.pool != nil {
+		item = s.pool.acquire(item)
+	}
+	s.unshare()
+	s.m[item] = struct{}{}
+	s.version++
+	if s.metrics != nil {
+		s.metrics.adds.Add(1)
+	}
+	if len(s.observers) > 0 {
+		s.added = append(s.added, item)
+	}
+	if s.journal != nil {
+		s.journal.record(item, true)
+	}
+	if s.wal != nil {
+		s.wal.record(item, true)
+	}
+
+	if s.ids != nil {
+		if _, ok := s.ids[item]; !ok {
+			s.ids[item] = len(s.items)
+			s.items = append(s.items, item)
+		}
+	}
+	return true
+}
+
+// contains reports whether item is in s. The read lock must be held.
+func (s *Set) contains(item interface{}) bool {
+	_, ok := s.m[normalize(item)]
+	if s.metrics != nil {
+		s.metrics.lookup(ok)
+	}
+	return ok
+}
+
+// delete removes item from s and reports whether it existed. The write lock
+// must be held.
+func (s *Set) delete(item interface{}) bool {
+	item = normalize(item)
+	if _, ok := s.m[item]; !ok {
+		return false
+	}
+	s.unshare()
+	delete(s.m, item)
+	s.removedCount++
+	s.version++
+	if s.metrics != nil {
+		s.metrics.removes.Add(1)
+	}
+	if len(s.observers) > 0 {
+		s.removed = append(s.removed, item)
+	}
+	if s.journal != nil {
+		s.journal.record(item, false)
+	}
+	if s.wal != nil {
+		s.wal.record(item, false)
+	}
+
+	if s.pool != nil {
+		s.pool.release(item)
+	}
+	return true
+}
+
+func (s *Set) typematch(t Interface) error {
+	if s.kind != t.Kind() {
+		return fmt.Errorf("cannot perform the requested operation on mismatched sets; '%s' != '%s'", s.kind.String(), t.Kind().String())
 	}
 	return nil
 }
 
 func (s *Set) typecheck(items ...interface{}) error {
+	return typecheck(s.kind, items...)
+}
+
+func typecheck(kind reflect.Kind, items ...interface{}) error {
+	if !IsSupportedKind(kind) {
+		return fmt.Errorf("set kind '%s' is not supported", kind.String())
+	}
 	for _, item := range items {
+		if item == nil {
+			return fmt.Errorf("tried to insert nil into a set of kind '%s'", kind.String())
+		}
 		k := reflect.TypeOf(item).Kind()
-		if k != s.kind {
-			return fmt.Errorf("tried to insert value of kind '%s' into a set of kind '%s'", k.String(), s.kind.String())
+		if k != kind {
+			return fmt.Errorf("tried to insert value of kind '%s' into a set of kind '%s'", k.String(), kind.String())
+		}
+		if !reflect.ValueOf(item).Comparable() {
+			return fmt.Errorf("tried to insert an unhashable value of type '%T' into a set of kind '%s'", item, kind.String())
 		}
 	}
 	return nil
@@ -2,11 +2,14 @@ package goset
 
 import (
 	"reflect"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 )
 
 func TestSet_New(t *testing.T) {
-	s := New()
+	s := New(reflect.Int)
 
 	if s.Size() != 0 {
 		t.Error("New: calling withtout any parameters should create a set with zero size")
@@ -15,7 +18,7 @@ func TestSet_New(t *testing.T) {
 }
 
 func TestSet_New_parameters(t *testing.T) {
-	s := New("string", "another_string", 1, 3.14)
+	s := New(reflect.String, "string", "another_string", "1", "3.14")
 
 	if s.Size() != 4 {
 		t.Error("New: calling with parameters should create a set with size of four")
@@ -23,10 +26,10 @@ func TestSet_New_parameters(t *testing.T) {
 }
 
 func TestSet_Add(t *testing.T) {
-	s := New()
-	s.Add(1)
-	s.Add(2)
-	s.Add(2) // duplicate
+	s := New(reflect.String)
+	s.Add("1")
+	s.Add("2")
+	s.Add("2") // duplicate
 	s.Add("fatih")
 	s.Add("zeynep")
 	s.Add("zeynep") // another duplicate
@@ -35,41 +38,62 @@ func TestSet_Add(t *testing.T) {
 		t.Error("Add: items are not unique. The set size should be four")
 	}
 
-	if !s.Has(1, 2, "fatih", "zeynep") {
+	if ok, _ := s.Has("1", "2", "fatih", "zeynep"); !ok {
 		t.Error("Add: added items are not availabile in the set.")
 	}
 }
 
 func TestSet_Add_multiple(t *testing.T) {
-	s := New()
-	s.Add("ankara", "san francisco", 3.14)
+	s := New(reflect.String)
+	s.Add("ankara", "san francisco", "3.14")
 
 	if s.Size() != 3 {
 		t.Error("Add: items are not unique. The set size should be three")
 	}
 
-	if !s.Has("ankara", "san francisco", 3.14) {
+	if ok, _ := s.Has("ankara", "san francisco", "3.14"); !ok {
 		t.Error("Add: added items are not availabile in the set.")
 	}
 }
 
+func TestSet_AddReport(t *testing.T) {
+	s := New(reflect.String, "ankara")
+
+	added, err := s.AddReport("ankara", "berlin", "istanbul", "berlin")
+	if err != nil {
+		t.Fatal("AddReport: unexpected error", err)
+	}
+
+	if added != 2 {
+		t.Errorf("AddReport: two items should be new, got %d", added)
+	}
+
+	if added, _ := s.AddReport("ankara"); added != 0 {
+		t.Error("AddReport: adding an existing item should report zero")
+	}
+
+	if added, err := s.AddReport("izmir", 1); err == nil || added != 0 || s.Size() != 3 {
+		t.Error("AddReport: items of another kind should return an error and add nothing")
+	}
+}
+
 func TestSet_Remove(t *testing.T) {
-	s := New()
-	s.Add(1)
-	s.Add(2)
+	s := New(reflect.String)
+	s.Add("1")
+	s.Add("2")
 	s.Add("fatih")
 
-	s.Remove(1)
+	s.Remove("1")
 	if s.Size() != 2 {
 		t.Error("Remove: set size should be two after removing")
 	}
 
-	s.Remove(1)
+	s.Remove("1")
 	if s.Size() != 2 {
 		t.Error("Remove: set size should be not change after trying to remove a non-existing item")
 	}
 
-	s.Remove(2)
+	s.Remove("2")
 	s.Remove("fatih")
 	if s.Size() != 0 {
 		t.Error("Remove: set size should be zero")
@@ -79,34 +103,199 @@ func TestSet_Remove(t *testing.T) {
 }
 
 func TestSet_Remove_multiple(t *testing.T) {
-	s := New()
-	s.Add("ankara", "san francisco", 3.14, "istanbul")
-	s.Remove("ankara", "san francisco", 3.14)
+	s := New(reflect.String)
+	s.Add("ankara", "san francisco", "3.14", "istanbul")
+	s.Remove("ankara", "san francisco", "3.14")
 
 	if s.Size() != 1 {
 		t.Error("Remove: items are not unique. The set size should be four")
 	}
 
-	if !s.Has("istanbul") {
+	if ok, _ := s.Has("istanbul"); !ok {
 		t.Error("Add: added items are not availabile in the set.")
 	}
 }
 
+func TestSet_RemoveReport(t *testing.T) {
+	s := New(reflect.String, "ankara", "berlin")
+
+	if existed, err := s.RemoveReport("ankara"); err != nil || !existed {
+		t.Error("RemoveReport: removing an existing item should report true")
+	}
+
+	if existed, _ := s.RemoveReport("ankara"); existed {
+		t.Error("RemoveReport: removing a missing item should report false")
+	}
+
+	if _, err := s.RemoveReport(1); err == nil {
+		t.Error("RemoveReport: items of another kind should return an error")
+	}
+
+	if s.Size() != 1 {
+		t.Error("RemoveReport: set size should be one")
+	}
+}
+
+func TestSet_GetOrAdd(t *testing.T) {
+	s := New(reflect.String, "ankara")
+
+	if existing, err := s.GetOrAdd("berlin"); err != nil || existing {
+		t.Error("GetOrAdd: adding a missing item should report false")
+	}
+	if existing, _ := s.GetOrAdd("ankara"); !existing {
+		t.Error("GetOrAdd: adding an existing item should report true")
+	}
+	if _, err := s.GetOrAdd(1); err == nil {
+		t.Error("GetOrAdd: items of another kind should return an error")
+	}
+
+	if s.Size() != 2 {
+		t.Error("GetOrAdd: set size should be two")
+	}
+}
+
+func TestSet_CompareAndSwap(t *testing.T) {
+	s := New(reflect.String, "pending:1", "done:2")
+
+	if swapped, err := s.CompareAndSwap("pending:1", "done:1"); err != nil || !swapped {
+		t.Error("CompareAndSwap: a member should be swapped")
+	}
+	if ok, _ := s.Has("pending:1"); ok {
+		t.Error("CompareAndSwap: the old item should be removed")
+	}
+	if swapped, _ := s.CompareAndSwap("pending:1", "done:1"); swapped {
+		t.Error("CompareAndSwap: a missing item should not be swapped")
+	}
+	if swapped, _ := s.CompareAndSwap("done:1", "done:2"); swapped {
+		t.Error("CompareAndSwap: an item should not be swapped with a member")
+	}
+	if _, err := s.CompareAndSwap("done:1", 2); err == nil {
+		t.Error("CompareAndSwap: items of another kind should return an error")
+	}
+
+	if ok, _ := s.Has("done:1", "done:2"); !ok || s.Size() != 2 {
+		t.Errorf("CompareAndSwap: expected [done:1 done:2], got %s", s)
+	}
+}
+
+func TestSet_Find(t *testing.T) {
+	s := New(reflect.String, "ankara", "berlin", "paris")
+
+	item, ok := s.Find(func(item interface{}) bool { return strings.HasPrefix(item.(string), "b") })
+	if !ok || item != "berlin" {
+		t.Errorf("Find: expected berlin, got %v", item)
+	}
+
+	calls := 0
+	s.Find(func(interface{}) bool {
+		calls++
+		return true
+	})
+	if calls != 1 {
+		t.Errorf("Find: should stop at the first match, got %d calls", calls)
+	}
+
+	if item, ok := s.Find(func(interface{}) bool { return false }); ok || item != nil {
+		t.Errorf("Find: expected no match, got %v", item)
+	}
+}
+
+func TestSet_CountFunc(t *testing.T) {
+	s := New(reflect.Int, 1, 2, 3, 4, 5)
+
+	if n := s.CountFunc(func(item interface{}) bool { return item.(int) > 2 }); n != 3 {
+		t.Errorf("CountFunc: expected 3, got %d", n)
+	}
+	if n := New(reflect.Int).CountFunc(func(interface{}) bool { return true }); n != 0 {
+		t.Errorf("CountFunc: expected 0 for an empty set, got %d", n)
+	}
+}
+
+func TestSet_RemoveIf(t *testing.T) {
+	s := New(reflect.Int, 1, 2, 3, 4, 5)
+
+	n := s.RemoveIf(func(item interface{}) bool { return item.(int)%2 == 1 })
+	if n != 3 {
+		t.Errorf("RemoveIf: expected three items to be removed, got %d", n)
+	}
+	if ok, _ := s.Has(2, 4); !ok || s.Size() != 2 {
+		t.Errorf("RemoveIf: expected [2 4], got %s", s)
+	}
+
+	if n := s.RemoveIf(func(interface{}) bool { return false }); n != 0 || s.Size() != 2 {
+		t.Error("RemoveIf: nothing should be removed if nothing matches")
+	}
+}
+
 func TestSet_Has(t *testing.T) {
-	s := New("1", "2", "3", "4")
+	s := New(reflect.String, "1", "2", "3", "4")
 
-	if !s.Has("1") {
+	if ok, _ := s.Has("1"); !ok {
 		t.Error("Has: the item 1 exist, but 'Has' is returning false")
 	}
 
-	if !s.Has("1", "2", "3", "4") {
+	if ok, _ := s.Has("1", "2", "3", "4"); !ok {
 		t.Error("Has: the items all exist, but 'Has' is returning false")
 	}
 }
 
+func TestSet_ContainsAll(t *testing.T) {
+	s := New(reflect.String, "1", "2", "3")
+
+	if ok, _ := s.ContainsAll("1", "3"); !ok {
+		t.Error("ContainsAll: the items all exist, but it returns false")
+	}
+
+	if ok, _ := s.ContainsAll("1", "4"); ok {
+		t.Error("ContainsAll: item 4 doesn't exist, but it returns true")
+	}
+}
+
+func TestSet_ContainsNone(t *testing.T) {
+	s := New(reflect.String, "1", "2", "3")
+
+	if ok, _ := s.ContainsNone("4", "5"); !ok {
+		t.Error("ContainsNone: none of the items exist, but it returns false")
+	}
+
+	if ok, _ := s.ContainsNone("4", "2"); ok {
+		t.Error("ContainsNone: item 2 exists, but it returns true")
+	}
+
+	if ok, _ := s.ContainsNone(); !ok {
+		t.Error("ContainsNone: calling without items should return true")
+	}
+
+	if _, err := s.ContainsNone(4); err == nil {
+		t.Error("ContainsNone: items of another kind should return an error")
+	}
+}
+
+func TestSet_HasEach(t *testing.T) {
+	s := New(reflect.String, "1", "2", "3")
+
+	found, err := s.HasEach("1", "4", "3", "5")
+	if err != nil {
+		t.Fatal("HasEach: unexpected error", err)
+	}
+
+	expected := []bool{true, false, true, false}
+	if !reflect.DeepEqual(found, expected) {
+		t.Errorf("HasEach: expected %v, got %v", expected, found)
+	}
+
+	if found, _ := s.HasEach(); len(found) != 0 {
+		t.Error("HasEach: calling without items should return an empty slice")
+	}
+
+	if _, err := s.HasEach("1", 2); err == nil {
+		t.Error("HasEach: items of another kind should return an error")
+	}
+}
+
 func TestSet_Clear(t *testing.T) {
-	s := New()
-	s.Add(1)
+	s := New(reflect.String)
+	s.Add("1")
 	s.Add("istanbul")
 	s.Add("san francisco")
 
@@ -117,7 +306,7 @@ func TestSet_Clear(t *testing.T) {
 }
 
 func TestSet_IsEmpty(t *testing.T) {
-	s := New()
+	s := New(reflect.Int)
 
 	empty := s.IsEmpty()
 	if !empty {
@@ -134,25 +323,25 @@ func TestSet_IsEmpty(t *testing.T) {
 }
 
 func TestSet_IsEqual(t *testing.T) {
-	s := New("1", "2", "3")
-	u := New("1", "2", "3")
+	s := New(reflect.String, "1", "2", "3")
+	u := New(reflect.String, "1", "2", "3")
 
-	ok := s.IsEqual(u)
+	ok, _ := s.IsEqual(u)
 	if !ok {
 		t.Error("IsEqual: set s and t are equal. However it returns false")
 	}
 }
 
 func TestSet_IsSubset(t *testing.T) {
-	s := New("1", "2", "3", "4")
-	u := New("1", "2", "3")
+	s := New(reflect.String, "1", "2", "3", "4")
+	u := New(reflect.String, "1", "2", "3")
 
-	ok := s.IsSubset(u)
+	ok, _ := s.IsSubset(u)
 	if !ok {
 		t.Error("IsSubset: u is a subset of s. However it returns false")
 	}
 
-	ok = u.IsSubset(s)
+	ok, _ = u.IsSubset(s)
 	if ok {
 		t.Error("IsSubset: s is not a subset of u. However it returns true")
 	}
@@ -160,31 +349,61 @@ func TestSet_IsSubset(t *testing.T) {
 }
 
 func TestSet_IsSuperset(t *testing.T) {
-	s := New("1", "2", "3", "4")
-	u := New("1", "2", "3")
+	s := New(reflect.String, "1", "2", "3", "4")
+	u := New(reflect.String, "1", "2", "3")
 
-	ok := u.IsSuperset(s)
+	ok, _ := u.IsSuperset(s)
 	if !ok {
 		t.Error("IsSuperset: s is a superset of u. However it returns false")
 	}
 
-	ok = s.IsSuperset(u)
+	ok, _ = s.IsSuperset(u)
 	if ok {
 		t.Error("IsSuperset: u is not a superset of u. However it returns true")
 	}
 
 }
 
+func TestSet_IsDisjoint(t *testing.T) {
+	s := New(reflect.String, "1", "2", "3")
+	u := New(reflect.String, "4", "5")
+
+	if ok, _ := s.IsDisjoint(u); !ok {
+		t.Error("IsDisjoint: s and u share no items. However it returns false")
+	}
+
+	u.Add("3")
+	if ok, _ := u.IsDisjoint(s); ok {
+		t.Error("IsDisjoint: s and u share item 3. However it returns true")
+	}
+
+	if ok, _ := s.IsDisjoint(New(reflect.String)); !ok {
+		t.Error("IsDisjoint: any set is disjoint with the empty set")
+	}
+
+	if _, err := s.IsDisjoint(New(reflect.Int)); err == nil {
+		t.Error("IsDisjoint: sets of different kinds should return an error")
+	}
+}
+
 func TestSet_String(t *testing.T) {
-	s := New("1", "2", "3", "4")
+	s := New(reflect.String, "1", "2", "3", "4")
+
+	str := s.String()
+	if !strings.HasPrefix(str, "[") || !strings.HasSuffix(str, "]") {
+		t.Fatalf("String: output is not what is excepted, got %s", str)
+	}
 
-	if s.String() != "[1, 2, 3, 4]" {
-		t.Error("String: output is not what is excepted")
+	// the order of the items is not defined
+	items := strings.Split(strings.Trim(str, "[]"), ", ")
+	sort.Strings(items)
+	if strings.Join(items, ", ") != "1, 2, 3, 4" {
+		t.Errorf("String: output is not what is excepted, got %s", str)
 	}
 }
 
 func TestSet_List(t *testing.T) {
-	s := New("1", "2", "3", "4")
+	s := New(reflect.String, "1", "2", "3", "4")
 
 	// this returns a slice of interface{}
 	if len(s.List()) != 4 {
@@ -200,100 +419,215 @@ func TestSet_List(t *testing.T) {
 }
 
 func TestSet_Copy(t *testing.T) {
-	s := New("1", "2", "3", "4")
+	s := New(reflect.String, "1", "2", "3", "4")
 	r := s.Copy()
 
-	if !s.IsEqual(r) {
+	if ok, _ := s.IsEqual(r); !ok {
 		t.Error("Copy: set s and r are not equal")
 	}
 }
 
 func TestSet_Union(t *testing.T) {
-	s := New("1", "2", "3")
-	r := New("3", "4", "5")
-	u := s.Union(r)
+	s := New(reflect.String, "1", "2", "3")
+	r := New(reflect.String, "3", "4", "5")
+	u, _ := s.Union(r)
 
 	if u.Size() != 5 {
 		t.Error("Union: the merged set doesn't have all items in it.")
 	}
 
-	if !u.Has("1", "2", "3", "4", "5") {
+	if ok, _ := u.Has("1", "2", "3", "4", "5"); !ok {
 		t.Error("Union: merged items are not availabile in the set.")
 	}
 }
 
 func TestSet_Merge(t *testing.T) {
-	s := New("1", "2", "3")
-	r := New("3", "4", "5")
+	s := New(reflect.String, "1", "2", "3")
+	r := New(reflect.String, "3", "4", "5")
 	s.Merge(r)
 
 	if s.Size() != 5 {
 		t.Error("Merge: the set doesn't have all items in it.")
 	}
 
-	if !s.Has("1", "2", "3", "4", "5") {
+	if ok, _ := s.Has("1", "2", "3", "4", "5"); !ok {
 		t.Error("Merge: merged items are not availabile in the set.")
 	}
 }
 
 func TestSet_Separate(t *testing.T) {
-	s := New("1", "2", "3")
-	r := New("3", "5")
+	s := New(reflect.String, "1", "2", "3")
+	r := New(reflect.String, "3", "5")
 	s.Separate(r)
 
 	if s.Size() != 2 {
 		t.Error("Separate: the set doesn't have all items in it.")
 	}
 
-	if !s.Has("1") || !s.Has("2") {
+	if ok, _ := s.Has("1", "2"); !ok {
 		t.Error("Separate: items after separation are not availabile in the set.")
 	}
 }
 
+func TestSet_MergeConcurrent(t *testing.T) {
+	s := New(reflect.Int, 1, 2)
+	r := New(reflect.Int, 3, 4)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			s.Merge(r)
+		}()
+		go func() {
+			defer wg.Done()
+			r.Merge(s)
+		}()
+	}
+	wg.Wait()
+
+	if ok, _ := s.IsEqual(r); !ok {
+		t.Errorf("MergeConcurrent: sets should be equal, got %v and %v", s, r)
+	}
+}
+
+func TestSet_UpdateSelf(t *testing.T) {
+	s := New(reflect.Int, 1, 2, 3)
+
+	s.Merge(s)
+	if s.Size() != 3 {
+		t.Errorf("UpdateSelf: merging a set with itself shouldn't change it, got %v", s)
+	}
+
+	s.RetainAll(s)
+	if s.Size() != 3 {
+		t.Errorf("UpdateSelf: retaining a set's own items shouldn't change it, got %v", s)
+	}
+
+	s.SymmetricDifferenceUpdate(s)
+	if !s.IsEmpty() {
+		t.Errorf("UpdateSelf: symmetric difference with itself should be empty, got %v", s)
+	}
+}
+
+func TestSet_RetainAll(t *testing.T) {
+	s := New(reflect.String, "1", "2", "3")
+	r := New(reflect.String, "2", "3", "5")
+	s.RetainAll(r)
+
+	if s.Size() != 2 {
+		t.Error("RetainAll: the set should have two items left")
+	}
+
+	if ok, _ := s.Has("2", "3"); !ok {
+		t.Error("RetainAll: common items are not availabile in the set.")
+	}
+
+	if r.Size() != 3 {
+		t.Error("RetainAll: the other set should not be modified")
+	}
+
+	if err := s.RetainAll(New(reflect.Int)); err == nil || s.Size() != 2 {
+		t.Error("RetainAll: sets of different kinds should return an error")
+	}
+}
+
 func TestSet_Intersection(t *testing.T) {
-	s := New("1", "2", "3")
-	r := New("3", "5")
-	u := s.Intersection(r)
+	s := New(reflect.String, "1", "2", "3")
+	r := New(reflect.String, "3", "5")
+	u, _ := s.Intersection(r)
 
 	if u.Size() != 1 {
 		t.Error("Intersection: the set doesn't have all items in it.")
 	}
 
-	if !u.Has("3") {
+	if ok, _ := u.Has("3"); !ok {
 		t.Error("Intersection: items after intersection are not availabile in the set.")
 	}
 }
 
 func TestSet_Difference(t *testing.T) {
-	s := New("1", "2", "3")
-	r := New("2", "3", "5")
-	u := s.Difference(r)
+	s := New(reflect.String, "1", "2", "3")
+	r := New(reflect.String, "2", "3", "5")
+	u, _ := s.Difference(r)
 
 	if u.Size() != 1 {
 		t.Error("Difference: the set doesn't have all items in it.")
 	}
 
-	if !u.Has("1") {
+	if ok, _ := u.Has("1"); !ok {
 		t.Error("Difference: items are not availabile in the set.")
 	}
 }
 
 func TestSet_SymmetricDifference(t *testing.T) {
-	s := New("1", "2", "3")
-	r := New("3", "4", "5")
-	u := s.SymmetricDifference(r)
+	s := New(reflect.String, "1", "2", "3")
+	r := New(reflect.String, "3", "4", "5")
+	u, _ := s.SymmetricDifference(r)
 
 	if u.Size() != 4 {
 		t.Error("SymmetricDifference: the set doesn't have all items in it.")
 	}
 
-	if !u.Has("1", "2", "4", "5") {
+	if ok, _ := u.Has("1", "2", "4", "5"); !ok {
 		t.Error("SymmetricDifference: items are not availabile in the set.")
 	}
 }
 
+func TestSet_SymmetricDifferenceUpdate(t *testing.T) {
+	s := New(reflect.String, "1", "2", "3")
+	r := New(reflect.String, "3", "4", "5")
+	s.SymmetricDifferenceUpdate(r)
+
+	if s.Size() != 4 {
+		t.Error("SymmetricDifferenceUpdate: the set doesn't have all items in it.")
+	}
+
+	if ok, _ := s.Has("1", "2", "4", "5"); !ok {
+		t.Error("SymmetricDifferenceUpdate: items are not availabile in the set.")
+	}
+
+	s.SymmetricDifferenceUpdate(s)
+	if !s.IsEmpty() {
+		t.Error("SymmetricDifferenceUpdate: applying a set on itself should empty it")
+	}
+}
+
+func TestSet_AppendTo(t *testing.T) {
+	s := New(reflect.Int, 1, 2, 3)
+
+	buf := make([]interface{}, 0, 8)
+	buf = append(buf, 0)
+	buf = s.AppendTo(buf)
+	if len(buf) != 4 || buf[0] != 0 {
+		t.Errorf("AppendTo: expected the items after the existing ones, got %v", buf)
+	}
+
+	allocs := testing.AllocsPerRun(10, func() {
+		buf = s.AppendTo(buf[:0])
+	})
+	if allocs != 0 {
+		t.Errorf("AppendTo: expected no allocation with a large enough buffer, got %v", allocs)
+	}
+
+	ints := s.AppendInts(make([]int, 0, 3))
+	sort.Ints(ints)
+	if !reflect.DeepEqual(ints, []int{1, 2, 3}) {
+		t.Errorf("AppendInts: expected [1 2 3], got %v", ints)
+	}
+
+	strs := New(reflect.String, "a").AppendStrings([]string{"z"})
+	if !reflect.DeepEqual(strs, []string{"z", "a"}) {
+		t.Errorf("AppendStrings: expected [z a], got %v", strs)
+	}
+	if strs := s.AppendStrings(nil); len(strs) != 0 {
+		t.Errorf("AppendStrings: items of other types should be skipped, got %v", strs)
+	}
+}
+
 func TestSet_StringSlice(t *testing.T) {
-	s := New("san francisco", "istanbul", 3.14, 1321, "ankara")
+	s := New(reflect.String, "san francisco", "istanbul", "ankara")
 	u := s.StringSlice()
 
 	if len(u) != 3 {
@@ -309,7 +643,7 @@ func TestSet_StringSlice(t *testing.T) {
 }
 
 func TestSet_IntSlice(t *testing.T) {
-	s := New("san francisco", "istanbul", 3.14, 1321, "ankara", 8876)
+	s := New(reflect.Int, 1321, 8876)
 	u := s.IntSlice()
 
 	if len(u) != 2 {
@@ -323,3 +657,162 @@ func TestSet_IntSlice(t *testing.T) {
 		}
 	}
 }
+
+func TestSet_Any(t *testing.T) {
+	s := New(reflect.Int, 1, 2, 3)
+
+	if !s.Any(func(item interface{}) bool { return item.(int) > 2 }) {
+		t.Error("Any: item 3 matches, but 'Any' is returning false")
+	}
+
+	if s.Any(func(item interface{}) bool { return item.(int) > 3 }) {
+		t.Error("Any: no item matches, but 'Any' is returning true")
+	}
+
+	if New(reflect.Int).Any(func(item interface{}) bool { return true }) {
+		t.Error("Any: an empty set should return false")
+	}
+}
+
+func TestSet_All(t *testing.T) {
+	s := New(reflect.Int, 1, 2, 3)
+
+	if !s.All(func(item interface{}) bool { return item.(int) > 0 }) {
+		t.Error("All: all items match, but 'All' is returning false")
+	}
+
+	if s.All(func(item interface{}) bool { return item.(int) > 1 }) {
+		t.Error("All: item 1 doesn't match, but 'All' is returning true")
+	}
+
+	if !New(reflect.Int).All(func(item interface{}) bool { return false }) {
+		t.Error("All: an empty set should return true")
+	}
+}
+
+func TestSet_None(t *testing.T) {
+	s := New(reflect.Int, 1, 2, 3)
+
+	if !s.None(func(item interface{}) bool { return item.(int) > 3 }) {
+		t.Error("None: no item matches, but 'None' is returning false")
+	}
+
+	if s.None(func(item interface{}) bool { return item.(int) == 2 }) {
+		t.Error("None: item 2 matches, but 'None' is returning true")
+	}
+}
+
+func TestSet_Partition(t *testing.T) {
+	s := New(reflect.Int, 1, 2, 3, 4, 5)
+	even, odd := s.Partition(func(item interface{}) bool { return item.(int)%2 == 0 })
+
+	if even.Size() != 2 || odd.Size() != 3 {
+		t.Error("Partition: the sets should have two and three items")
+	}
+
+	if ok, _ := even.Has(2, 4); !ok {
+		t.Error("Partition: matching items are not available in the first set")
+	}
+
+	if ok, _ := odd.Has(1, 3, 5); !ok {
+		t.Error("Partition: other items are not available in the second set")
+	}
+
+	if s.Size() != 5 {
+		t.Error("Partition: the original set should not be modified")
+	}
+}
+
+func TestSet_GroupBy(t *testing.T) {
+	s := New(reflect.String, "ankara", "amsterdam", "berlin", "istanbul", "izmir")
+	groups := s.GroupBy(func(item interface{}) interface{} { return item.(string)[0] })
+
+	if len(groups) != 3 {
+		t.Error("GroupBy: there should be three groups")
+	}
+
+	if ok, _ := groups[byte('a')].Has("ankara", "amsterdam"); !ok || groups[byte('a')].Size() != 2 {
+		t.Error("GroupBy: group 'a' should contain ankara and amsterdam")
+	}
+
+	if ok, _ := groups[byte('i')].Has("istanbul", "izmir"); !ok || groups[byte('i')].Size() != 2 {
+		t.Error("GroupBy: group 'i' should contain istanbul and izmir")
+	}
+
+	if len(New(reflect.String).GroupBy(func(item interface{}) interface{} { return 0 })) != 0 {
+		t.Error("GroupBy: an empty set should produce no groups")
+	}
+}
+
+func benchmarkItems(n int) (*Set, []interface{}) {
+	s := New(reflect.Int)
+	items := make([]interface{}, n)
+	for i := range items {
+		items[i] = i
+	}
+	s.Add(items...)
+	return s, items
+}
+
+func BenchmarkSet_Has_perItem(b *testing.B) {
+	s, items := benchmarkItems(100)
+	for i := 0; i < b.N; i++ {
+		for _, item := range items {
+			s.Has(item)
+		}
+	}
+}
+
+func BenchmarkSet_ContainsAll(b *testing.B) {
+	s, items := benchmarkItems(100)
+	for i := 0; i < b.N; i++ {
+		s.ContainsAll(items...)
+	}
+}
+
+func BenchmarkSet_ContainsNone(b *testing.B) {
+	s, items := benchmarkItems(100)
+	for i := 0; i < b.N; i++ {
+		s.ContainsNone(items...)
+	}
+}
+
+func TestSet_Int64Slice(t *testing.T) {
+	s := New(reflect.Int64, int64(1), int64(1<<40))
+	u := s.Int64Slice()
+
+	if len(u) != 2 {
+		t.Error("Int64Slice: slice should have two items")
+	}
+}
+
+func TestSet_UintSlice(t *testing.T) {
+	s := New(reflect.Uint, uint(1), uint(2), uint(3))
+	u := s.UintSlice()
+
+	if len(u) != 3 {
+		t.Error("UintSlice: slice should have three items")
+	}
+}
+
+func TestSet_Float64Slice(t *testing.T) {
+	s := New(reflect.Float64, 3.14, 2.71)
+	u := s.Float64Slice()
+
+	if len(u) != 2 {
+		t.Error("Float64Slice: slice should have two items")
+	}
+
+	if len(New(reflect.Float32, float32(1)).Float64Slice()) != 0 {
+		t.Error("Float64Slice: float32 items should be skipped")
+	}
+}
+
+func TestSet_BoolSlice(t *testing.T) {
+	s := New(reflect.Bool, true, false, true)
+	u := s.BoolSlice()
+
+	if len(u) != 2 {
+		t.Error("BoolSlice: slice should have two items")
+	}
+}
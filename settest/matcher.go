@@ -0,0 +1,70 @@
+package settest
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/fatih/goset"
+)
+
+// Matcher matches sets passed as arguments to mocks. It implements the
+// Matcher interface of gomock, and its Matches method can be passed to
+// testify's mock.MatchedBy:
+//
+//	store.EXPECT().Save(settest.SetContaining("a", "b"))
+//	store.On("Save", mock.MatchedBy(settest.SetEqualTo("a", "b").Matches))
+//
+// Any goset.Interface, such as a *goset.Set, can be matched. Other values
+// never match.
+type Matcher struct {
+	desc  string
+	match func(s goset.Interface) bool
+}
+
+// Matches reports whether x is a set matching m.
+func (m Matcher) Matches(x interface{}) bool {
+	s, ok := x.(goset.Interface)
+	if !ok {
+		return false
+	}
+	if v := reflect.ValueOf(s); v.Kind() == reflect.Pointer && v.IsNil() {
+		return false
+	}
+	return m.match(s)
+}
+
+// String describes what m matches.
+func (m Matcher) String() string {
+	return m.desc
+}
+
+// SetContaining returns a Matcher for sets that contain all the given items,
+// and possibly others.
+func SetContaining(items ...interface{}) Matcher {
+	return Matcher{
+		desc: fmt.Sprintf("is a set containing %v", items),
+		match: func(s goset.Interface) bool {
+			if len(items) == 0 {
+				return true
+			}
+			ok, err := s.Has(items...)
+			return ok && err == nil
+		},
+	}
+}
+
+// SetEqualTo returns a Matcher for sets that contain exactly the given items.
+// Duplicates in items are ignored.
+func SetEqualTo(items ...interface{}) Matcher {
+	return Matcher{
+		desc: fmt.Sprintf("is a set equal to %v", items),
+		match: func(s goset.Interface) bool {
+			expected := goset.New(s.Kind())
+			if err := expected.Add(items...); err != nil {
+				return false
+			}
+			ok, err := expected.IsEqual(s)
+			return ok && err == nil
+		},
+	}
+}
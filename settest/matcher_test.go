@@ -0,0 +1,45 @@
+package settest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/fatih/goset"
+)
+
+func TestSetContaining(t *testing.T) {
+	s := goset.New(reflect.String, "a", "b", "c")
+
+	if !SetContaining("a", "c").Matches(s) {
+		t.Error("SetContaining: should match a set with the items")
+	}
+	if SetContaining("a", "d").Matches(s) {
+		t.Error("SetContaining: should not match a set missing an item")
+	}
+	if SetContaining(1).Matches(s) {
+		t.Error("SetContaining: should not match items of another kind")
+	}
+	if SetContaining("a").Matches([]string{"a"}) || SetContaining("a").Matches((*goset.Set)(nil)) {
+		t.Error("SetContaining: should not match values that aren't sets")
+	}
+	if got := SetContaining("a").String(); got != "is a set containing [a]" {
+		t.Errorf("String: unexpected description %q", got)
+	}
+}
+
+func TestSetEqualTo(t *testing.T) {
+	s := goset.New(reflect.Int, 1, 2)
+
+	if !SetEqualTo(2, 1, 1).Matches(s) {
+		t.Error("SetEqualTo: should match a set with exactly the items")
+	}
+	if SetEqualTo(1).Matches(s) || SetEqualTo(1, 2, 3).Matches(s) {
+		t.Error("SetEqualTo: should not match a set with other items")
+	}
+	if SetEqualTo(1, "2").Matches(s) {
+		t.Error("SetEqualTo: should not match items of another kind")
+	}
+	if !SetEqualTo().Matches(goset.New(reflect.Int)) {
+		t.Error("SetEqualTo: should match an empty set")
+	}
+}
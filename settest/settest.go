@@ -0,0 +1,124 @@
+// Package settest provides helpers for testing code that uses goset.
+package settest
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/fatih/goset"
+)
+
+// TB is the subset of testing.TB used by the helpers in this package.
+type TB interface {
+	Helper()
+	Cleanup(func())
+	Errorf(format string, args ...interface{})
+}
+
+// LeakTimeout is how long VerifyNoLeaks waits for goroutines and observers of
+// the goset package to go away before reporting them.
+var LeakTimeout = time.Second
+
+var pkgPath = reflect.TypeOf(goset.Set{}).PkgPath()
+
+// VerifyNoLeaks fails the test if goroutines belonging to the goset package,
+// such as the ones driving watchers, are still running when the test
+// finishes, or if observers registered on any of the given sets, with OnAdd,
+// OnRemove, OnSizeThreshold, Watch or ForEachMember, were not cancelled.
+// Goroutines and observers that already existed when VerifyNoLeaks was called
+// are ignored. Call it at the start of a test:
+//
+//	func TestFoo(t *testing.T) {
+//		s := goset.New(reflect.String)
+//		settest.VerifyNoLeaks(t, s)
+//		...
+//	}
+func VerifyNoLeaks(t TB, sets ...*goset.Set) {
+	t.Helper()
+
+	before := make(map[string]bool)
+	for _, g := range gosetGoroutines() {
+		before[goroutineID(g)] = true
+	}
+	observers := make(map[uint64]bool)
+	for _, s := range sets {
+		for _, id := range s.ObserverIDs() {
+			observers[id] = true
+		}
+	}
+
+	t.Cleanup(func() {
+		var (
+			leaked   []string
+			deadline = time.Now().Add(LeakTimeout)
+		)
+		for {
+			leaked = leaked[:0]
+			for _, g := range gosetGoroutines() {
+				if !before[goroutineID(g)] {
+					leaked = append(leaked, g)
+				}
+			}
+			for i, s := range sets {
+				for _, id := range s.ObserverIDs() {
+					if !observers[id] {
+						leaked = append(leaked, fmt.Sprintf("observer %d of set %d, %s", id, i, s))
+					}
+				}
+			}
+			if len(leaked) == 0 || time.Now().After(deadline) {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		if len(leaked) > 0 {
+			t.Errorf("settest: %d leak(s) of %s:\n\n%s", len(leaked), pkgPath, strings.Join(leaked, "\n\n"))
+		}
+	})
+}
+
+// gosetGoroutines returns the stack traces of all goroutines that are running
+// code of the goset package or were started by it.
+func gosetGoroutines() []string {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	self := goroutineID(string(buf[:bytes.IndexByte(buf, '\n')]))
+
+	var gs []string
+	for _, g := range strings.Split(string(buf), "\n\n") {
+		if goroutineID(g) == self {
+			continue
+		}
+		for _, line := range strings.Split(g, "\n") {
+			line = strings.TrimPrefix(line, "created by ")
+			if strings.HasPrefix(line, pkgPath+".") {
+				gs = append(gs, g)
+				break
+			}
+		}
+	}
+	return gs
+}
+
+// goroutineID returns the id from the "goroutine N [state]:" header of a
+// stack trace.
+func goroutineID(stack string) string {
+	fields := strings.Fields(stack)
+	if len(fields) < 2 {
+		return ""
+	}
+	return fields[1]
+}
@@ -0,0 +1,103 @@
+package settest
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fatih/goset"
+)
+
+type fakeTB struct {
+	cleanups []func()
+	errors   []string
+}
+
+func (f *fakeTB) Helper()           {}
+func (f *fakeTB) Cleanup(fn func()) { f.cleanups = append(f.cleanups, fn) }
+func (f *fakeTB) Errorf(format string, args ...interface{}) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeTB) finish() {
+	for i := len(f.cleanups) - 1; i >= 0; i-- {
+		f.cleanups[i]()
+	}
+}
+
+func TestVerifyNoLeaks(t *testing.T) {
+	defer func(d time.Duration) { LeakTimeout = d }(LeakTimeout)
+	LeakTimeout = 50 * time.Millisecond
+
+	s := goset.New(reflect.Int, 1)
+
+	tb := &fakeTB{}
+	VerifyNoLeaks(tb)
+
+	// a goroutine blocked inside the goset package
+	block := make(chan struct{})
+	running := make(chan struct{})
+	go s.Any(func(item interface{}) bool {
+		close(running)
+		<-block
+		return true
+	})
+	<-running
+
+	tb.finish()
+	if len(tb.errors) != 1 || !strings.Contains(tb.errors[0], "(*Set).Any") {
+		t.Errorf("VerifyNoLeaks: expected the blocked goroutine to be reported, got %v", tb.errors)
+	}
+
+	close(block)
+
+	tb = &fakeTB{}
+	VerifyNoLeaks(tb)
+	tb.finish()
+	if len(tb.errors) != 0 {
+		t.Errorf("VerifyNoLeaks: expected no leaks, got %v", tb.errors)
+	}
+}
+
+func TestVerifyNoLeaks_observers(t *testing.T) {
+	defer func(d time.Duration) { LeakTimeout = d }(LeakTimeout)
+	LeakTimeout = 50 * time.Millisecond
+
+	s := goset.New(reflect.Int)
+	cancel := s.OnAdd(func([]interface{}) {}) // registered before, not a leak
+
+	tb := &fakeTB{}
+	VerifyNoLeaks(tb, s)
+	s.OnRemove(func([]interface{}) {})
+	cancel() // doesn't make up for the leaked observer
+	tb.finish()
+	if len(tb.errors) != 1 || !strings.Contains(tb.errors[0], "of set 0") {
+		t.Errorf("VerifyNoLeaks: expected the observer to be reported, got %v", tb.errors)
+	}
+
+	s = goset.New(reflect.Int)
+	tb = &fakeTB{}
+	VerifyNoLeaks(tb, s)
+	ctx, stop := context.WithCancel(context.Background())
+	s.Watch(ctx)
+	tb.finish()
+	if len(tb.errors) != 1 || !strings.Contains(tb.errors[0], "of set 0") {
+		t.Errorf("VerifyNoLeaks: expected the running watch to be reported, got %v", tb.errors)
+	}
+
+	stop()
+
+	s = goset.New(reflect.Int)
+	tb = &fakeTB{}
+	VerifyNoLeaks(tb, s)
+	ctx, stop = context.WithCancel(context.Background())
+	s.Watch(ctx)
+	stop() // the watch is unregistered asynchronously
+	tb.finish()
+	if len(tb.errors) != 0 {
+		t.Errorf("VerifyNoLeaks: expected the stopped watch not to be reported, got %v", tb.errors)
+	}
+}
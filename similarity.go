@@ -0,0 +1,48 @@
+package goset
+
+// SimilarityReport holds similarity measures of two sets, see Set.Similarity.
+type SimilarityReport struct {
+	Intersection int // number of items in both sets
+	Union        int // number of items in either set
+
+	Jaccard float64 // size of the intersection over size of the union
+	Dice    float64 // Sørensen–Dice coefficient: twice the intersection over the sum of the sizes
+	Overlap float64 // overlap coefficient: intersection over the size of the smaller set
+}
+
+// Similarity computes the Jaccard index, the Sørensen–Dice coefficient and
+// the overlap coefficient of s and t, without building intermediate sets. All
+// measures range from 0, for disjoint sets, to 1. Two empty sets are
+// considered identical.
+func (s *Set) Similarity(t Interface) (SimilarityReport, error) {
+	if err := s.typematch(t); err != nil {
+		return SimilarityReport{}, err
+	}
+	other := asSet(t)
+	unlock := rlockAll([]*Set{s, other})
+
+	small, large := s, other
+	if len(small.m) > len(large.m) {
+		small, large = large, small
+	}
+	inter := 0
+	for item := range small.m {
+		if _, ok := large.m[item]; ok {
+			inter++
+		}
+	}
+	ns, nl := len(small.m), len(large.m)
+	unlock()
+
+	r := SimilarityReport{Intersection: inter, Union: ns + nl - inter}
+	if r.Union == 0 {
+		r.Jaccard, r.Dice, r.Overlap = 1, 1, 1
+		return r, nil
+	}
+	r.Jaccard = float64(inter) / float64(r.Union)
+	r.Dice = 2 * float64(inter) / float64(ns+nl)
+	if ns > 0 {
+		r.Overlap = float64(inter) / float64(ns)
+	}
+	return r, nil
+}
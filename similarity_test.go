@@ -0,0 +1,37 @@
+package goset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSet_Similarity(t *testing.T) {
+	a := New(reflect.Int, 1, 2, 3, 4)
+	b := New(reflect.Int, 3, 4, 5)
+
+	r, err := a.Similarity(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := SimilarityReport{
+		Intersection: 2,
+		Union:        5,
+		Jaccard:      0.4,
+		Dice:         4.0 / 7,
+		Overlap:      2.0 / 3,
+	}
+	if r != expected {
+		t.Errorf("Similarity: expected %+v, got %+v", expected, r)
+	}
+
+	if r, _ := New(reflect.Int).Similarity(New(reflect.Int)); r.Jaccard != 1 || r.Dice != 1 || r.Overlap != 1 {
+		t.Errorf("Similarity: two empty sets should be identical, got %+v", r)
+	}
+	if r, _ := a.Similarity(New(reflect.Int)); r.Jaccard != 0 || r.Dice != 0 || r.Overlap != 0 {
+		t.Errorf("Similarity: a set and an empty set should be disjoint, got %+v", r)
+	}
+
+	if _, err := a.Similarity(New(reflect.String)); err == nil {
+		t.Error("Similarity: sets of mismatched kinds should return an error")
+	}
+}
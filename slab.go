@@ -0,0 +1,212 @@
+package goset
+
+import (
+	"hash/maphash"
+	"sync"
+)
+
+// slabSize is the size of the byte slabs a SlabStringSet copies its strings
+// into. Longer strings get a slab of their own.
+const slabSize = 1 << 20
+
+// SlabStringSet is a thread safe set of strings meant for sets of tens of
+// millions of items. Instead of storing boxed strings in a map, which the
+// garbage collector has to scan item by item, it copies the strings into large
+// byte slabs and indexes them with a pointer-free open addressing table. The
+// garbage collector only sees a handful of large objects without pointers,
+// whatever the number of items.
+//
+// The bytes of removed strings are reclaimed when the table is rebuilt, which
+// happens as it grows or fills up with removed entries.
+type SlabStringSet struct {
+	l     sync.RWMutex
+	seed  maphash.Seed
+	slabs [][]byte
+	table []slabEntry
+	size  int // live entries
+	used  int // live and removed entries
+}
+
+// slabEntry locates a string in the slabs. It holds no pointers.
+type slabEntry struct {
+	slab uint32 // index in slabs plus one, zero for an empty entry
+	off  uint32
+	n    uint32
+	hash uint32 // low bits of the hash, to skip most comparisons
+}
+
+// tombstone marks the slab of a removed entry.
+const tombstone = ^uint32(0)
+
+// NewSlabStringSet creates a new SlabStringSet populated with items.
+func NewSlabStringSet(items ...string) *SlabStringSet {
+	s := &SlabStringSet{
+		seed:  maphash.MakeSeed(),
+		table: make([]slabEntry, 16),
+	}
+	s.Add(items...)
+	return s
+}
+
+// Add includes the specified items to the set and returns the number of items
+// that were not in the set yet.
+func (s *SlabStringSet) Add(items ...string) int {
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	added := 0
+	for _, item := range items {
+		h := maphash.String(s.seed, item)
+		i, found := s.find(item, h)
+		if found {
+			continue
+		}
+		if s.table[i].slab == 0 {
+			s.used++
+		}
+		s.table[i] = s.store(item, h)
+		s.size++
+		added++
+
+		if s.used*4 >= len(s.table)*3 {
+			s.rehash()
+		}
+	}
+	return added
+}
+
+// Remove deletes the specified items from the set and returns the number of
+// items that were removed.
+func (s *SlabStringSet) Remove(items ...string) int {
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	removed := 0
+	for _, item := range items {
+		if i, found := s.find(item, maphash.String(s.seed, item)); found {
+			s.table[i] = slabEntry{slab: tombstone}
+			s.size--
+			removed++
+		}
+	}
+	return removed
+}
+
+// Has looks for the existence of items passed. It returns false if nothing is
+// passed. For multiple items it returns true only if all of the items exist.
+func (s *SlabStringSet) Has(items ...string) bool {
+	if len(items) == 0 {
+		return false
+	}
+
+	s.l.RLock()
+	defer s.l.RUnlock()
+
+	for _, item := range items {
+		if _, found := s.find(item, maphash.String(s.seed, item)); !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Size returns the number of items in the set.
+func (s *SlabStringSet) Size() int {
+	s.l.RLock()
+	defer s.l.RUnlock()
+	return s.size
+}
+
+// Each calls fn for every item of the set until fn returns false. The set is
+// read locked during the iteration, fn must not modify it.
+func (s *SlabStringSet) Each(fn func(item string) bool) {
+	s.l.RLock()
+	defer s.l.RUnlock()
+
+	for _, e := range s.table {
+		if e.slab != 0 && e.slab != tombstone && !fn(s.load(e)) {
+			return
+		}
+	}
+}
+
+// List returns a slice of all items.
+func (s *SlabStringSet) List() []string {
+	list := make([]string, 0, s.Size())
+	s.Each(func(item string) bool {
+		list = append(list, item)
+		return true
+	})
+	return list
+}
+
+// find returns the index of item in the table and whether it's there. If it's
+// not, the index is where it should be inserted. The lock must be held.
+func (s *SlabStringSet) find(item string, h uint64) (int, bool) {
+	mask := len(s.table) - 1
+	insert := -1
+	for i := int(h) & mask; ; i = (i + 1) & mask {
+		e := s.table[i]
+		switch {
+		case e.slab == 0:
+			if insert < 0 {
+				insert = i
+			}
+			return insert, false
+		case e.slab == tombstone:
+			if insert < 0 {
+				insert = i
+			}
+		case e.hash == uint32(h) && int(e.n) == len(item) &&
+			string(s.slabs[e.slab-1][e.off:e.off+e.n]) == item: // doesn't allocate
+			return i, true
+		}
+	}
+}
+
+// load returns a copy of the string located by e.
+func (s *SlabStringSet) load(e slabEntry) string {
+	return string(s.slabs[e.slab-1][e.off : e.off+e.n])
+}
+
+// store copies item into the slabs and returns its entry.
+func (s *SlabStringSet) store(item string, h uint64) slabEntry {
+	n := len(s.slabs)
+	if n == 0 || len(s.slabs[n-1])+len(item) > cap(s.slabs[n-1]) {
+		size := slabSize
+		if len(item) > size {
+			size = len(item)
+		}
+		s.slabs = append(s.slabs, make([]byte, 0, size))
+		n++
+	}
+
+	slab := s.slabs[n-1]
+	e := slabEntry{slab: uint32(n), off: uint32(len(slab)), n: uint32(len(item)), hash: uint32(h)}
+	s.slabs[n-1] = append(slab, item...)
+	return e
+}
+
+// rehash rebuilds the table, doubling it if more than half of it is live, and
+// copies the live strings into fresh slabs to drop the bytes of removed ones.
+func (s *SlabStringSet) rehash() {
+	size := len(s.table)
+	if s.size*2 >= size {
+		size *= 2
+	}
+
+	old, oldSlabs := s.table, s.slabs
+	s.table = make([]slabEntry, size)
+	s.slabs = nil
+	s.used = s.size
+
+	for _, e := range old {
+		if e.slab == 0 || e.slab == tombstone {
+			continue
+		}
+		item := string(oldSlabs[e.slab-1][e.off : e.off+e.n])
+		h := maphash.String(s.seed, item)
+		i, _ := s.find(item, h)
+		s.table[i] = s.store(item, h)
+	}
+}
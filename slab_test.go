@@ -0,0 +1,64 @@
+package goset
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestSlabStringSet(t *testing.T) {
+	s := NewSlabStringSet("ankara", "berlin", "")
+
+	if added := s.Add("berlin", "istanbul"); added != 1 {
+		t.Errorf("SlabStringSet: expected one new item, got %d", added)
+	}
+
+	if s.Size() != 4 || !s.Has("ankara", "berlin", "istanbul", "") {
+		t.Error("SlabStringSet: added items are not available in the set")
+	}
+
+	if removed := s.Remove("ankara", "paris"); removed != 1 || s.Has("ankara") {
+		t.Error("SlabStringSet: removed item should be gone")
+	}
+
+	list := s.List()
+	sort.Strings(list)
+	if strings.Join(list, ",") != ",berlin,istanbul" {
+		t.Errorf("SlabStringSet: unexpected items %v", list)
+	}
+}
+
+func TestSlabStringSet_large(t *testing.T) {
+	s := NewSlabStringSet()
+	long := strings.Repeat("x", slabSize+1)
+
+	for i := 0; i < 100000; i++ {
+		s.Add(fmt.Sprintf("item%d", i))
+	}
+	s.Add(long)
+
+	for i := 0; i < 100000; i += 2 {
+		s.Remove(fmt.Sprintf("item%d", i))
+	}
+
+	// re-adding removed items reuses their entries
+	for i := 0; i < 1000; i += 2 {
+		s.Add(fmt.Sprintf("item%d", i))
+	}
+
+	if s.Size() != 50000+500+1 {
+		t.Fatalf("SlabStringSet: unexpected size %d", s.Size())
+	}
+
+	for i := 0; i < 100000; i++ {
+		want := i%2 == 1 || i < 1000
+		if s.Has(fmt.Sprintf("item%d", i)) != want {
+			t.Fatalf("SlabStringSet: item%d should be present: %v", i, want)
+		}
+	}
+
+	if !s.Has(long) {
+		t.Error("SlabStringSet: strings longer than a slab should be stored")
+	}
+}
@@ -0,0 +1,161 @@
+package goset
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// IntersectSlice returns the items of the given slice that are members of s,
+// in their original order. Duplicates in items are kept. Items of another kind
+// are never members and are silently skipped.
+func (s *Set) IntersectSlice(items []interface{}) []interface{} {
+	res := make([]interface{}, 0)
+
+	s.rlock()
+	defer s.l.RUnlock()
+
+	for _, item := range items {
+		if s.accepts(item) && s.contains(item) {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
+// IntersectStrings is like IntersectSlice for a slice of strings.
+func (s *Set) IntersectStrings(items []string) []string {
+	res := make([]string, 0)
+
+	s.rlock()
+	defer s.l.RUnlock()
+
+	for _, item := range items {
+		if s.accepts(item) && s.contains(item) {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
+// IntersectInts is like IntersectSlice for a slice of ints.
+func (s *Set) IntersectInts(items []int) []int {
+	res := make([]int, 0)
+
+	s.rlock()
+	defer s.l.RUnlock()
+
+	for _, item := range items {
+		if s.accepts(item) && s.contains(item) {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
+// FilterSliceNotIn returns the elements of slice that are not members of s,
+// in their original order. slice can be a slice of any type, for example a
+// []string or []int64, and the result is a new slice of the same type. It's
+// the typical "which of these incoming items are new?" operation:
+//
+//	fresh, err := seen.FilterSliceNotIn(ids)
+//	for _, id := range fresh.([]int64) { ... }
+//
+// Elements of another kind than the set are never members and are kept. An
+// error is returned if slice is not a slice.
+func (s *Set) FilterSliceNotIn(slice interface{}) (interface{}, error) {
+	v := reflect.ValueOf(slice)
+	if v.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("FilterSliceNotIn: expected a slice, got '%T'", slice)
+	}
+
+	res := reflect.MakeSlice(v.Type(), 0, v.Len())
+
+	s.rlock()
+	defer s.l.RUnlock()
+
+	for i := 0; i < v.Len(); i++ {
+		item := v.Index(i).Interface()
+		if s.accepts(item) && s.contains(item) {
+			continue
+		}
+		res = reflect.Append(res, v.Index(i))
+	}
+	return res.Interface(), nil
+}
+
+// accepts reports whether item can be looked up in s without an error: it must
+// be of the kind of the set and hashable.
+func (s *Set) accepts(item interface{}) bool {
+	return accepts(s.kind, item)
+}
+
+func accepts(kind reflect.Kind, item interface{}) bool {
+	if item == nil {
+		return false
+	}
+	v := reflect.ValueOf(item)
+	return v.Kind() == kind && v.Comparable()
+}
+
+// SliceOf returns all items of s as a []T. It returns an error if an item is
+// not a T, which can't happen for a set whose kind matches T unless T is a
+// named type or an interface. See SliceOfSkip to skip such items instead.
+func SliceOf[T any](s *Set) ([]T, error) {
+	s.rlock()
+	defer s.l.RUnlock()
+
+	slice := make([]T, 0, len(s.m))
+	for item := range s.m {
+		v, ok := item.(T)
+		if !ok {
+			return nil, fmt.Errorf("SliceOf: item %v of type '%T' is not a '%s'", item, item, reflect.TypeOf((*T)(nil)).Elem())
+		}
+		slice = append(slice, v)
+	}
+	return slice, nil
+}
+
+// SliceOfSkip is like SliceOf, but silently skips the items which are not a T,
+// like StringSlice and IntSlice do.
+func SliceOfSkip[T any](s *Set) []T {
+	s.rlock()
+	defer s.l.RUnlock()
+
+	slice := make([]T, 0, len(s.m))
+	for item := range s.m {
+		if v, ok := item.(T); ok {
+			slice = append(slice, v)
+		}
+	}
+	return slice
+}
+
+// NewFromSlice creates a new Set holding the elements of slice, which must be
+// a slice or an array, such as a []string, a []int or a []MyType. The kind of
+// the set is the kind of the element type. For a slice of interfaces the kind
+// is the kind of its first element, and every element must be of that kind.
+func NewFromSlice(slice interface{}) (*Set, error) {
+	v := reflect.ValueOf(slice)
+	if k := v.Kind(); k != reflect.Slice && k != reflect.Array {
+		return nil, fmt.Errorf("cannot create a set from '%T', it's not a slice or an array", slice)
+	}
+
+	items := make([]interface{}, v.Len())
+	for i := range items {
+		items[i] = v.Index(i).Interface()
+	}
+
+	kind := v.Type().Elem().Kind()
+	if kind == reflect.Interface {
+		if len(items) == 0 || items[0] == nil {
+			return nil, fmt.Errorf("cannot infer the kind of a set from '%T'", slice)
+		}
+		kind = reflect.TypeOf(items[0]).Kind()
+	}
+
+	s := New(kind)
+	if err := s.Add(items...); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,128 @@
+package goset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSet_IntersectSlice(t *testing.T) {
+	s := New(reflect.String, "1", "2", "3")
+	res := s.IntersectSlice([]interface{}{"3", "4", 1, nil, []int{1}, "1", "3"})
+
+	expected := []interface{}{"3", "1", "3"}
+	if !reflect.DeepEqual(res, expected) {
+		t.Errorf("IntersectSlice: expected %v, got %v", expected, res)
+	}
+}
+
+func TestSet_IntersectStrings(t *testing.T) {
+	s := New(reflect.String, "ankara", "berlin")
+	res := s.IntersectStrings([]string{"istanbul", "berlin", "ankara"})
+
+	if !reflect.DeepEqual(res, []string{"berlin", "ankara"}) {
+		t.Errorf("IntersectStrings: unexpected result %v", res)
+	}
+
+	m := NewWithOptions(reflect.String, WithMetrics(), WithItems("ankara"))
+	m.IntersectStrings([]string{"istanbul", "ankara"})
+	if metrics := m.Metrics(); metrics.Lookups != 2 || metrics.Hits != 1 {
+		t.Errorf("IntersectStrings: lookups should be counted, got %+v", metrics)
+	}
+}
+
+func TestSet_IntersectInts(t *testing.T) {
+	s := New(reflect.Int, 1, 2, 3)
+	res := s.IntersectInts([]int{5, 3, 4, 1})
+
+	if !reflect.DeepEqual(res, []int{3, 1}) {
+		t.Errorf("IntersectInts: unexpected result %v", res)
+	}
+
+	if res := New(reflect.String, "1").IntersectInts([]int{1}); len(res) != 0 {
+		t.Error("IntersectInts: ints are never members of a string set")
+	}
+}
+
+func TestSet_FilterSliceNotIn(t *testing.T) {
+	s := New(reflect.Int64, int64(1), int64(2), int64(3))
+
+	res, err := s.FilterSliceNotIn([]int64{4, 1, 5, 3, 4})
+	if err != nil {
+		t.Fatal("FilterSliceNotIn: unexpected error", err)
+	}
+
+	if !reflect.DeepEqual(res, []int64{4, 5, 4}) {
+		t.Errorf("FilterSliceNotIn: unexpected result %v", res)
+	}
+
+	res, _ = s.FilterSliceNotIn([]interface{}{int64(1), 2, []int{}})
+	if len(res.([]interface{})) != 2 {
+		t.Errorf("FilterSliceNotIn: items of another kind should be kept, got %v", res)
+	}
+
+	if _, err := s.FilterSliceNotIn(1); err == nil {
+		t.Error("FilterSliceNotIn: passing a non slice should return an error")
+	}
+}
+
+func TestSliceOf(t *testing.T) {
+	s := New(reflect.Int64, int64(1), int64(2))
+
+	slice, err := SliceOf[int64](s)
+	if err != nil || len(slice) != 2 {
+		t.Fatalf("SliceOf: expected two int64 items, got %v (%v)", slice, err)
+	}
+
+	if _, err := SliceOf[int](s); err == nil {
+		t.Error("SliceOf: extracting int64 items as int should return an error")
+	}
+}
+
+func TestSliceOfSkip(t *testing.T) {
+	type id int
+
+	s := New(reflect.Int, 1, id(2), id(3))
+
+	if ids := SliceOfSkip[id](s); len(ids) != 2 {
+		t.Errorf("SliceOfSkip: expected two ids, got %v", ids)
+	}
+
+	if ints := SliceOfSkip[int](s); len(ints) != 1 || ints[0] != 1 {
+		t.Errorf("SliceOfSkip: expected a single int, got %v", ints)
+	}
+}
+
+func TestNewFromSlice(t *testing.T) {
+	type color string
+
+	s, err := NewFromSlice([]color{"red", "green", "red"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := s.Has(color("red"), color("green")); !ok || s.Size() != 2 || s.Kind() != reflect.String {
+		t.Errorf("NewFromSlice: expected a string set [red green], got %s", s)
+	}
+
+	s, err = NewFromSlice([3]int{1, 2, 3})
+	if err != nil || s.Size() != 3 || s.Kind() != reflect.Int {
+		t.Errorf("NewFromSlice: expected an int set [1 2 3], got %v, %v", s, err)
+	}
+
+	s, err = NewFromSlice([]interface{}{1.5, 2.5})
+	if err != nil || s.Kind() != reflect.Float64 {
+		t.Errorf("NewFromSlice: the kind should be inferred from the elements, got %v, %v", s, err)
+	}
+
+	if _, err := NewFromSlice([]interface{}{1, "a"}); err == nil {
+		t.Error("NewFromSlice: elements of mixed kinds should return an error")
+	}
+	if _, err := NewFromSlice([]interface{}{}); err == nil {
+		t.Error("NewFromSlice: an empty slice of interfaces should return an error")
+	}
+	if _, err := NewFromSlice([][]int{{1}}); err == nil {
+		t.Error("NewFromSlice: unhashable elements should return an error")
+	}
+	if _, err := NewFromSlice("abc"); err == nil {
+		t.Error("NewFromSlice: a non slice should return an error")
+	}
+}
@@ -0,0 +1,88 @@
+package goset
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// InClause is a chunk of the items of a set, ready to be used in a SQL IN
+// clause: Placeholders is of the form "(?,?,?)" with one placeholder for every
+// element of Args.
+type InClause struct {
+	Placeholders string
+	Args         []interface{}
+}
+
+// InClauses splits the items of s into chunks of at most max items (all items
+// in one chunk if max is zero or negative) and returns the placeholders and
+// arguments for every chunk:
+//
+//	for _, in := range ids.InClauses(500) {
+//		rows, err := db.Query("SELECT name FROM users WHERE id IN "+in.Placeholders, in.Args...)
+//		...
+//	}
+//
+// An empty set returns no chunks, as "IN ()" is not valid SQL.
+func (s *Set) InClauses(max int) []InClause {
+	items := s.List()
+	if max <= 0 {
+		max = len(items)
+	}
+
+	clauses := make([]InClause, 0)
+	for len(items) > 0 {
+		n := max
+		if n > len(items) {
+			n = len(items)
+		}
+
+		clauses = append(clauses, InClause{
+			Placeholders: "(" + strings.TrimSuffix(strings.Repeat("?,", n), ",") + ")",
+			Args:         items[:n:n],
+		})
+		items = items[n:]
+	}
+	return clauses
+}
+
+// Value implements driver.Valuer: s is stored as a JSON array of its items,
+// in sorted order so that equal sets are stored identically.
+func (s *Set) Value() (driver.Value, error) {
+	data, err := json.Marshal(s.sortedList())
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner. It replaces the items of s with the JSON array
+// stored in src, as written by Value. A NULL column empties s. Values are
+// converted to the kind of s; the kind of a zero Set is inferred from them.
+func (s *Set) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		return s.replaceWith(nil)
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return fmt.Errorf("goset: cannot scan a set from '%T'", src)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var values []interface{}
+	if err := dec.Decode(&values); err != nil {
+		return fmt.Errorf("goset: scanning set: %s", err)
+	}
+	if err := s.replaceWith(values); err != nil {
+		return fmt.Errorf("goset: scanning set: %s", err)
+	}
+	return nil
+}
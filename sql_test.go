@@ -0,0 +1,97 @@
+package goset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSet_InClauses(t *testing.T) {
+	s := New(reflect.Int, 1, 2, 3, 4, 5)
+
+	clauses := s.InClauses(2)
+	if len(clauses) != 3 {
+		t.Fatalf("InClauses: expected three chunks, got %d", len(clauses))
+	}
+
+	expected := []string{"(?,?)", "(?,?)", "(?)"}
+	u := New(reflect.Int)
+	for i, in := range clauses {
+		if in.Placeholders != expected[i] {
+			t.Errorf("InClauses: expected %s, got %s", expected[i], in.Placeholders)
+		}
+		u.Add(in.Args...)
+	}
+
+	if ok, _ := s.IsEqual(u); !ok {
+		t.Error("InClauses: the arguments should contain all items of the set")
+	}
+
+	if clauses := s.InClauses(0); len(clauses) != 1 || len(clauses[0].Args) != 5 {
+		t.Error("InClauses: a zero max should return a single chunk")
+	}
+
+	if len(New(reflect.Int).InClauses(10)) != 0 {
+		t.Error("InClauses: an empty set should return no chunks")
+	}
+}
+
+func TestSet_Value(t *testing.T) {
+	v, err := New(reflect.Int, 3, 1, 2).Value()
+	if err != nil || v != "[1,2,3]" {
+		t.Errorf("Value: expected [1,2,3], got %v (%v)", v, err)
+	}
+
+	if v, _ := New(reflect.String).Value(); v != "[]" {
+		t.Errorf("Value: expected [], got %v", v)
+	}
+}
+
+func TestSet_Scan(t *testing.T) {
+	s := New(reflect.Int64, int64(9))
+	if err := s.Scan([]byte("[1, 2, 2]")); err != nil {
+		t.Fatal("Scan: unexpected error", err)
+	}
+	if ok, _ := s.Has(int64(1), int64(2)); !ok || s.Size() != 2 {
+		t.Errorf("Scan: expected [1 2], got %s", s)
+	}
+
+	if err := s.Scan(`["a"]`); err == nil {
+		t.Error("Scan: strings in an int64 set should return an error")
+	}
+
+	if err := s.Scan(nil); err != nil || !s.IsEmpty() {
+		t.Error("Scan: NULL should empty the set")
+	}
+
+	if err := s.Scan(1); err == nil {
+		t.Error("Scan: unsupported sources should return an error")
+	}
+}
+
+func TestSet_Scan_zero(t *testing.T) {
+	tests := []struct {
+		src  string
+		kind reflect.Kind
+	}{
+		{`["a", "b"]`, reflect.String},
+		{`[1, 2]`, reflect.Int},
+		{`[1, 2.5]`, reflect.Float64},
+		{`[true]`, reflect.Bool},
+	}
+
+	for _, test := range tests {
+		var s Set
+		if err := s.Scan(test.src); err != nil {
+			t.Fatalf("Scan: unexpected error for %s: %s", test.src, err)
+		}
+		if s.Kind() != test.kind || s.Size() == 0 {
+			t.Errorf("Scan: expected a %s set from %s, got %s", test.kind, test.src, &s)
+		}
+		s.Add(s.List()[0]) // the zero set must be usable afterwards
+	}
+
+	var s Set
+	if err := s.Scan(`["a", 1]`); err == nil {
+		t.Error("Scan: mixed values should return an error")
+	}
+}
@@ -0,0 +1,119 @@
+package goset
+
+import "reflect"
+
+const (
+	// mapHeaderBytes is the approximate fixed cost of the backing map.
+	mapHeaderBytes = 48
+
+	// mapEntryBytes is the approximate per item cost of the backing map: an
+	// interface{} key of two words, a control byte and the unused slots
+	// kept free by the map's load factor.
+	mapEntryBytes = 20
+
+	// interfaceBytes is the size of an interface{} value.
+	interfaceBytes = 16
+)
+
+// Stats describes the composition of a set. See Set.Stats.
+type Stats struct {
+	// Size is the number of items in the set.
+	Size int
+
+	// Types maps the name of every concrete type in the set (as printed by
+	// %T) to the number of items of that type.
+	Types map[string]int
+
+	// AvgStringLen is the average length in bytes of the string items, or
+	// zero if there are none.
+	AvgStringLen float64
+
+	// EstimatedBytes is a rough estimate of the memory held by the set, as
+	// returned by MemoryFootprint.
+	EstimatedBytes int64
+}
+
+// Stats inspects all items of s under a single read lock and reports what the
+// set is made of. It's meant for diagnostics, as it walks the whole set.
+func (s *Set) Stats() Stats {
+	st := Stats{Types: make(map[string]int)}
+
+	s.rlock()
+	defer s.l.RUnlock()
+
+	var strings, stringBytes int
+	for item := range s.m {
+		t := reflect.TypeOf(item)
+		st.Types[t.String()]++
+
+		if v, ok := item.(string); ok {
+			strings++
+			stringBytes += len(v)
+		}
+	}
+
+	st.Size = len(s.m)
+	st.EstimatedBytes = s.memoryFootprint()
+	if strings > 0 {
+		st.AvgStringLen = float64(stringBytes) / float64(strings)
+	}
+	return st
+}
+
+// MemoryFootprint returns an estimate in bytes of the memory held by s: the
+// backing map with the boxed items and string contents, plus the ID tables of
+// WithIDs and the journal of WithJournal if they're enabled. It walks the
+// whole set under a single read lock. It doesn't count memory referenced by
+// pointers, channels or interface fields of structs, nor strings shared
+// through an InternPool.
+func (s *Set) MemoryFootprint() int64 {
+	s.rlock()
+	defer s.l.RUnlock()
+
+	return s.memoryFootprint()
+}
+
+// memoryFootprint is MemoryFootprint without the lock. The read lock must be
+// held.
+func (s *Set) memoryFootprint() int64 {
+	n := int64(mapHeaderBytes)
+	for item := range s.m {
+		if s.pool != nil {
+			if _, ok := item.(string); ok {
+				n += mapEntryBytes + interfaceBytes
+				continue
+			}
+		}
+		n += mapEntryBytes + itemBytes(item)
+	}
+
+	if s.ids != nil {
+		// the ids map shares its keys with items, which keeps removed items
+		// alive too
+		n += mapHeaderBytes + int64(len(s.ids))*(mapEntryBytes+8)
+		n += int64(cap(s.items)) * interfaceBytes
+		for _, item := range s.items {
+			if _, ok := s.m[item]; !ok {
+				n += itemBytes(item)
+			}
+		}
+	}
+	if s.journal != nil {
+		n += int64(cap(s.journal.entries)) * (interfaceBytes + 16)
+	}
+	return n
+}
+
+// itemBytes estimates the heap memory used by item once it's boxed into an
+// interface{}.
+func itemBytes(item interface{}) int64 {
+	v := reflect.ValueOf(item)
+	switch v.Kind() {
+	case reflect.Pointer, reflect.UnsafePointer, reflect.Chan:
+		// pointer shaped values are stored in the interface directly
+		return 0
+	case reflect.String:
+		return int64(v.Type().Size()) + int64(v.Len())
+	}
+	return int64(v.Type().Size())
+}
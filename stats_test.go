@@ -0,0 +1,68 @@
+package goset
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSet_Stats(t *testing.T) {
+	s := New(reflect.String, "ab", "abcd", "abcdef")
+	st := s.Stats()
+
+	if st.Size != 3 {
+		t.Error("Stats: size should be three")
+	}
+
+	if st.Types["string"] != 3 || len(st.Types) != 1 {
+		t.Errorf("Stats: expected three strings, got %v", st.Types)
+	}
+
+	if st.AvgStringLen != 4 {
+		t.Errorf("Stats: average string length should be 4, got %v", st.AvgStringLen)
+	}
+
+	if st.EstimatedBytes <= New(reflect.String).Stats().EstimatedBytes {
+		t.Error("Stats: a filled set should be estimated larger than an empty one")
+	}
+}
+
+func TestSet_Stats_types(t *testing.T) {
+	type id struct{ n int }
+	type name struct{ s string }
+
+	s := New(reflect.Struct, id{1}, id{2}, name{"x"})
+	st := s.Stats()
+
+	if len(st.Types) != 2 || st.Types["goset.id"] != 2 || st.Types["goset.name"] != 1 {
+		t.Errorf("Stats: unexpected types %v", st.Types)
+	}
+
+	if st.AvgStringLen != 0 {
+		t.Error("Stats: average string length should be zero without string items")
+	}
+}
+
+func TestSet_MemoryFootprint(t *testing.T) {
+	s := New(reflect.String, "ab", "abcd")
+	if n, est := s.MemoryFootprint(), s.Stats().EstimatedBytes; n != est {
+		t.Errorf("MemoryFootprint: expected %d for a plain set, got %d", est, n)
+	}
+	interned := NewWithOptions(reflect.String, WithInternPool(NewInternPool()), WithItems("ab", "abcd"))
+	if n, est := interned.MemoryFootprint(), interned.Stats().EstimatedBytes; n != est || n >= s.MemoryFootprint() {
+		t.Errorf("Stats: expected the footprint %d of a set with an intern pool, got %d", n, est)
+	}
+
+	small := New(reflect.Int, 1).MemoryFootprint()
+	large := New(reflect.Int, 1, 2, 3, 4).MemoryFootprint()
+	if large <= small {
+		t.Errorf("MemoryFootprint: should grow with the size, got %d <= %d", large, small)
+	}
+
+	j := NewWithOptions(reflect.Int, WithJournal(), WithIDs(), WithItems(1))
+	plain := j.MemoryFootprint()
+	j.Add(2)
+	j.Remove(2)
+	if j.MemoryFootprint() <= plain {
+		t.Error("MemoryFootprint: should count the IDs and the journal")
+	}
+}
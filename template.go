@@ -0,0 +1,28 @@
+package goset
+
+import "text/template"
+
+// FuncMap returns functions to query sets from text/template and
+// html/template:
+//
+//	has        {{if has .Tags "beta"}}...{{end}}
+//	union      {{range sortedList (union .Admins .Owners)}}...{{end}}
+//	sortedList {{range sortedList .Tags}}{{.}} {{end}}
+//
+// has reports false for an item of another kind, union fails the template
+// execution for sets of different kinds. sortedList orders numbers and strings
+// by value and other kinds by their string representation.
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"has": func(s *Set, item interface{}) bool {
+			ok, _ := s.Has(item)
+			return ok
+		},
+		"union": func(s, t *Set) (*Set, error) {
+			return s.Union(t)
+		},
+		"sortedList": func(s *Set) []interface{} {
+			return s.sortedList()
+		},
+	}
+}
@@ -0,0 +1,52 @@
+package goset
+
+import (
+	htmltemplate "html/template"
+	"reflect"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestFuncMap(t *testing.T) {
+	data := map[string]*Set{
+		"a": New(reflect.Int, 10, 2, 33),
+		"b": New(reflect.Int, 2, 4),
+		"s": New(reflect.String, "x"),
+	}
+
+	tests := []struct{ tmpl, out string }{
+		{`{{range sortedList .a}}{{.}} {{end}}`, "2 10 33 "},
+		{`{{range sortedList (union .a .b)}}{{.}} {{end}}`, "2 4 10 33 "},
+		{`{{has .a 10}} {{has .a 11}} {{has .s 10}}`, "true false false"},
+	}
+
+	for _, test := range tests {
+		var out strings.Builder
+		tmpl := template.Must(template.New("").Funcs(FuncMap()).Parse(test.tmpl))
+		if err := tmpl.Execute(&out, data); err != nil {
+			t.Errorf("FuncMap: executing %q failed: %s", test.tmpl, err)
+		}
+		if out.String() != test.out {
+			t.Errorf("FuncMap: executing %q expected %q, got %q", test.tmpl, test.out, out.String())
+		}
+	}
+
+	tmpl := template.Must(template.New("").Funcs(FuncMap()).Parse(`{{union .a .s}}`))
+	if err := tmpl.Execute(&strings.Builder{}, data); err == nil {
+		t.Error("FuncMap: union of different kinds should fail")
+	}
+}
+
+func TestFuncMap_html(t *testing.T) {
+	tmpl := htmltemplate.Must(htmltemplate.New("").Funcs(FuncMap()).Parse(`{{range sortedList .}}<b>{{.}}</b>{{end}}`))
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, New(reflect.String, "b&", "a")); err != nil {
+		t.Fatal("FuncMap: unexpected error", err)
+	}
+
+	if out.String() != "<b>a</b><b>b&amp;</b>" {
+		t.Errorf("FuncMap: unexpected output %q", out.String())
+	}
+}
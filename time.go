@@ -0,0 +1,77 @@
+package goset
+
+import (
+	"sort"
+	"time"
+)
+
+// normalize returns the representation under which item is stored in a set.
+//
+// A time.Time is compared by the == operator, and so by map keys, on its
+// monotonic clock reading and location too: two times for the same instant can
+// be different keys. To make membership follow time.Time.Equal, times are
+// stored without monotonic clock reading and in UTC. Items read back from a
+// set of times are therefore always in UTC.
+func normalize(item interface{}) interface{} {
+	if t, ok := item.(time.Time); ok {
+		return t.Round(0).UTC()
+	}
+	return item
+}
+
+// now is replaced in tests.
+var now = time.Now
+
+// Between returns the time.Time items of s in the half-open interval
+// [from, to), in chronological order. Items of other types are skipped.
+func (s *Set) Between(from, to time.Time) []time.Time {
+	times := make([]time.Time, 0)
+	s.eachTime(func(t time.Time) {
+		if !t.Before(from) && t.Before(to) {
+			times = append(times, t)
+		}
+	})
+	sort.Slice(times, func(i, j int) bool {
+		return times[i].Before(times[j])
+	})
+	return times
+}
+
+// CountSince returns the number of time.Time items of s that are not older
+// than d, that is within [now-d, now]. Items in the future aren't counted.
+func (s *Set) CountSince(d time.Duration) int {
+	end := now()
+	start := end.Add(-d)
+
+	n := 0
+	s.eachTime(func(t time.Time) {
+		if !t.Before(start) && !t.After(end) {
+			n++
+		}
+	})
+	return n
+}
+
+// Buckets groups the time.Time items of s into buckets of the given size and
+// returns the number of items in every non empty bucket, keyed by the start of
+// the bucket. Buckets are aligned on multiples of size since the zero time, as
+// with time.Time.Truncate, and their start is in UTC.
+func (s *Set) Buckets(size time.Duration) map[time.Time]int {
+	buckets := make(map[time.Time]int)
+	s.eachTime(func(t time.Time) {
+		buckets[t.Truncate(size)]++
+	})
+	return buckets
+}
+
+// eachTime calls fn for every time.Time item of s under the read lock.
+func (s *Set) eachTime(fn func(t time.Time)) {
+	s.rlock()
+	defer s.l.RUnlock()
+
+	for item := range s.m {
+		if t, ok := item.(time.Time); ok {
+			fn(t)
+		}
+	}
+}
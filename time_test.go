@@ -0,0 +1,89 @@
+package goset
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSet_time(t *testing.T) {
+	now := time.Now() // has a monotonic clock reading
+	tokyo := now.In(time.FixedZone("JST", 9*60*60))
+	wall := now.Round(0)
+
+	s := New(reflect.Struct, now)
+	s.Add(tokyo, wall)
+
+	if s.Size() != 1 {
+		t.Fatalf("time: equal instants should be stored once, got %s", s)
+	}
+
+	if ok, _ := s.Has(tokyo); !ok {
+		t.Error("time: the instant should be found in another location")
+	}
+
+	item := s.List()[0].(time.Time)
+	if !item.Equal(now) || item.Location() != time.UTC || item != item.Round(0) {
+		t.Errorf("time: stored time should be in UTC without monotonic clock, got %#v", item)
+	}
+
+	if found, _ := s.HasEach(wall, now.Add(time.Nanosecond)); !found[0] || found[1] {
+		t.Errorf("time: unexpected membership %v", found)
+	}
+
+	if !s.Snapshot().Has(tokyo) {
+		t.Error("time: snapshots should look up times the same way")
+	}
+
+	s.Remove(tokyo)
+	if !s.IsEmpty() {
+		t.Error("time: removing the instant in another location should remove it")
+	}
+}
+
+func TestSet_Between(t *testing.T) {
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := New(reflect.Struct)
+	for i := 4; i >= 0; i-- {
+		s.Add(base.Add(time.Duration(i) * time.Hour))
+	}
+
+	times := s.Between(base.Add(time.Hour), base.Add(3*time.Hour))
+	expected := []time.Time{base.Add(time.Hour), base.Add(2 * time.Hour)}
+	if !reflect.DeepEqual(times, expected) {
+		t.Errorf("Between: expected %v, got %v", expected, times)
+	}
+}
+
+func TestSet_CountSince(t *testing.T) {
+	base := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	defer func() { now = time.Now }()
+	now = func() time.Time { return base }
+
+	s := New(reflect.Struct,
+		base.Add(-2*time.Hour),
+		base.Add(-30*time.Minute),
+		base.Add(-time.Minute),
+		base.Add(time.Minute),
+	)
+
+	if n := s.CountSince(time.Hour); n != 2 {
+		t.Errorf("CountSince: expected two items in the last hour, got %d", n)
+	}
+}
+
+func TestSet_Buckets(t *testing.T) {
+	base := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	s := New(reflect.Struct,
+		base,
+		base.Add(10*time.Minute),
+		base.Add(59*time.Minute),
+		base.Add(61*time.Minute),
+	)
+
+	buckets := s.Buckets(time.Hour)
+	expected := map[time.Time]int{base: 3, base.Add(time.Hour): 1}
+	if !reflect.DeepEqual(buckets, expected) {
+		t.Errorf("Buckets: expected %v, got %v", expected, buckets)
+	}
+}
@@ -0,0 +1,74 @@
+package goset
+
+// SetTx gives access to a set while its write lock is held, see Set.Do. It
+// must not be used after the function it was passed to returns.
+type SetTx struct {
+	s *Set
+}
+
+// Do runs fn while holding the write lock of s, so that a compound operation
+// is atomic with regards to every other operation on s:
+//
+//	s.Do(func(tx goset.SetTx) {
+//		if ok, _ := tx.Has("a"); ok {
+//			tx.Remove("a")
+//			tx.Add("b")
+//		}
+//	})
+//
+// fn must only access s through tx; calling methods of s directly deadlocks.
+//
+// The changes made by fn are written to the write-ahead log of s at once,
+// after fn returns, and Do returns the error if the log can't be written, see
+// NewWAL.
+func (s *Set) Do(fn func(tx SetTx)) error {
+	schedule("Do")
+	s.lock()
+	defer s.unlock()
+
+	fn(SetTx{s: s})
+	return s.flushWAL()
+}
+
+// Add is like Set.Add.
+func (tx SetTx) Add(items ...interface{}) error {
+	if err := tx.s.typecheck(items...); err != nil {
+		return err
+	}
+	for _, item := range items {
+		tx.s.insert(item)
+	}
+	return tx.s.walErr()
+}
+
+// Remove is like Set.Remove.
+func (tx SetTx) Remove(items ...interface{}) error {
+	if err := tx.s.typecheck(items...); err != nil {
+		return err
+	}
+	for _, item := range items {
+		tx.s.delete(item)
+	}
+	return tx.s.walErr()
+}
+
+// Has is like Set.Has.
+func (tx SetTx) Has(items ...interface{}) (bool, error) {
+	if len(items) == 0 {
+		return false, nil
+	}
+	if err := tx.s.typecheck(items...); err != nil {
+		return false, err
+	}
+	for _, item := range items {
+		if !tx.s.contains(item) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Size is like Set.Size.
+func (tx SetTx) Size() int {
+	return len(tx.s.m)
+}
@@ -0,0 +1,59 @@
+package goset
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestSet_Do(t *testing.T) {
+	s := New(reflect.String, "a")
+
+	s.Do(func(tx SetTx) {
+		if ok, _ := tx.Has("a"); ok {
+			tx.Remove("a")
+			tx.Add("b")
+		}
+		if tx.Size() != 1 {
+			t.Error("Do: size should be one inside the transaction")
+		}
+		if err := tx.Add(1); err == nil {
+			t.Error("Do: items of another kind should return an error")
+		}
+	})
+
+	if ok, _ := s.Has("b"); !ok || s.Size() != 1 {
+		t.Errorf("Do: set should be [b], got %s", s)
+	}
+}
+
+// TestSet_Do_atomic races the check-then-act pattern of TestReplay_hasThenAdd
+// through Do, where it can't insert twice.
+func TestSet_Do_atomic(t *testing.T) {
+	s := New(reflect.String)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		inserted int
+	)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.Do(func(tx SetTx) {
+				if ok, _ := tx.Has("x"); !ok {
+					tx.Add("x")
+					mu.Lock()
+					inserted++
+					mu.Unlock()
+				}
+			})
+		}()
+	}
+	wg.Wait()
+
+	if inserted != 1 {
+		t.Errorf("Do: expected a single insert, got %d", inserted)
+	}
+}
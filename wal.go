@@ -0,0 +1,355 @@
+package goset
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/fs"
+	"os"
+	"reflect"
+)
+
+// walMagic starts every write-ahead log. It's followed by the version of the
+// format and the kind of the set, making up a header of walHeaderSize bytes.
+const (
+	walMagic      = "GOSETWAL"
+	walVersion    = 1
+	walHeaderSize = len(walMagic) + 8
+)
+
+// walSnapshotSuffix is appended to the path of a log to get the path of the
+// snapshot it's compacted into.
+const walSnapshotSuffix = ".snapshot"
+
+// walCompactMin is the number of records a log must hold before it's
+// compacted. Beyond it, a log is compacted as soon as it holds more than twice
+// as many records as the set has items.
+const walCompactMin = 1024
+
+// wal is the write-ahead log of a set created with NewWAL or Recover. Every
+// record is framed by the length and the CRC-32 checksum of its gob encoding.
+type wal struct {
+	path    string
+	f       *os.File
+	buf     bytes.Buffer // records logged under the current write lock
+	pending int          // number of records in buf
+	records int          // number of records in f
+	err     error        // first failure, after which nothing is written
+}
+
+type walRecord struct {
+	Item  interface{}
+	Added bool // false if Item was removed
+}
+
+// errNoWAL is returned by the log methods of sets created without NewWAL or
+// Recover.
+var errNoWAL = errors.New("set has no write-ahead log, see NewWAL")
+
+// errTornRecord is returned by decodeWALRecord for a record that was only
+// partially written, as happens if the process crashes while appending it.
+var errTornRecord = errors.New("torn record")
+
+func (w *wal) record(item interface{}, added bool) {
+	if w.err != nil {
+		return
+	}
+
+	var payload bytes.Buffer
+	if err := gob.NewEncoder(&payload).Encode(walRecord{Item: item, Added: added}); err != nil {
+		w.err = fmt.Errorf("goset: logging %v: %s", item, err)
+		return
+	}
+
+	var frame [8]byte
+	binary.BigEndian.PutUint32(frame[:4], uint32(payload.Len()))
+	binary.BigEndian.PutUint32(frame[4:], crc32.ChecksumIEEE(payload.Bytes()))
+	w.buf.Write(frame[:])
+	w.buf.Write(payload.Bytes())
+	w.pending++
+}
+
+// NewWAL creates an empty set of the given kind in write-ahead log mode: every
+// item added or removed is appended to the log at path, which is synced to
+// disk before the operation returns. Use Recover to get the set back from the
+// log, after a restart or a crash. It returns an error if a file exists at
+// path already.
+//
+// The log is compacted into a snapshot, stored next to it with the
+// ".snapshot" suffix, as it grows. Items of named or struct types must be
+// registered with gob.Register, as with Save.
+//
+// Every method that modifies the set and returns an error writes the log
+// before it returns, and returns the error if the log can't be written.
+// Clear, PopRandom, RemoveIf and Import have no error to return: their
+// changes are written too, and a failure is reported by Err, as well as by the
+// next method returning an error. Once the log failed the set isn't logged
+// anymore, and Recover returns it as it was before the failure.
+func NewWAL(path string, kind reflect.Kind) (*Set, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	err = writeWALHeader(f, kind)
+	if err == nil {
+		err = f.Sync()
+	}
+	if err == nil {
+		// a snapshot left over by a removed log would be recovered with it
+		err = os.Remove(path + walSnapshotSuffix)
+		if errors.Is(err, fs.ErrNotExist) {
+			err = nil
+		}
+	}
+	if err != nil {
+		f.Close()
+		os.Remove(path)
+		return nil, err
+	}
+
+	s := New(kind)
+	s.wal = &wal{path: path, f: f}
+	return s, nil
+}
+
+// Recover returns the set logged to the write-ahead log at path by a set
+// created with NewWAL or Recover, which must be closed. The returned set
+// carries on logging to it.
+//
+// A record at the end of the log that was only partially written, because the
+// process crashed, is discarded. Any other damage to the log returns an error.
+func Recover(path string) (*Set, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	kind, err := readWALHeader(data)
+	if err != nil {
+		return nil, fmt.Errorf("goset: recovering %s: %s", path, err)
+	}
+
+	s, err := LoadFile(path + walSnapshotSuffix)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		s = New(kind)
+	case err != nil:
+		return nil, err
+	case s.kind != kind:
+		return nil, fmt.Errorf("goset: recovering %s: snapshot of kind '%s' doesn't match the log of kind '%s'", path, s.kind, kind)
+	}
+
+	off, records := walHeaderSize, 0
+	for off < len(data) {
+		rec, n, err := decodeWALRecord(data[off:])
+		if err == errTornRecord {
+			break
+		}
+		if err == nil {
+			err = typecheck(kind, rec.Item)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("goset: recovering %s: record at offset %d: %s", path, off, err)
+		}
+
+		// replaying a record is idempotent, so the log may overlap with the
+		// snapshot if a compaction was interrupted
+		if rec.Added {
+			s.insert(rec.Item)
+		} else {
+			s.delete(rec.Item)
+		}
+		off += n
+		records++
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return nil, err
+	}
+	if off < len(data) {
+		if err := f.Truncate(int64(off)); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+
+	s.wal = &wal{path: path, f: f, records: records}
+	return s, nil
+}
+
+// Err returns the first failure of the write-ahead log of s, which stops the
+// set from being logged, or nil if the log didn't fail. Check it after
+// methods that have no error to return, such as Clear, PopRandom, RemoveIf and
+// Import. It returns nil for sets without a log.
+func (s *Set) Err() error {
+	s.rlock()
+	defer s.l.RUnlock()
+
+	if s.wal == nil {
+		return nil
+	}
+	return s.wal.err
+}
+
+// CompactWAL writes a snapshot of s next to its write-ahead log and truncates
+// the log. Logs are compacted automatically as they grow, so it's only needed
+// to bound the time Recover takes at specific points. It returns an error if s
+// has no log.
+func (s *Set) CompactWAL() error {
+	s.lock()
+	defer s.unlock()
+
+	if s.wal == nil {
+		return errNoWAL
+	}
+	if err := s.flushWAL(); err != nil {
+		return err
+	}
+	if err := s.compactWAL(); err != nil {
+		s.wal.err = fmt.Errorf("goset: compacting log: %s", err)
+		return s.wal.err
+	}
+	return nil
+}
+
+// Close writes out and closes the write-ahead log of s. The set stays usable,
+// but isn't logged anymore. It's a no-op for sets without a log.
+func (s *Set) Close() error {
+	s.lock()
+	defer s.unlock()
+
+	if s.wal == nil {
+		return nil
+	}
+
+	err := s.flushWAL()
+	if cerr := s.wal.f.Close(); err == nil {
+		err = cerr
+	}
+	s.wal = nil
+	return err
+}
+
+// flushWAL writes the records logged under the current write lock to the log
+// of s and syncs it, then compacts the log if it grew too large. It returns
+// the first failure of the log, if any. The write lock must be held.
+func (s *Set) flushWAL() error {
+	w := s.wal
+	if w == nil {
+		return nil
+	}
+	if w.err != nil || w.pending == 0 {
+		return w.err
+	}
+
+	if _, err := w.f.Write(w.buf.Bytes()); err != nil {
+		w.err = fmt.Errorf("goset: writing log: %s", err)
+		return w.err
+	}
+	if err := w.f.Sync(); err != nil {
+		w.err = fmt.Errorf("goset: writing log: %s", err)
+		return w.err
+	}
+	w.records += w.pending
+	w.buf.Reset()
+	w.pending = 0
+
+	if w.records >= walCompactMin && w.records > 2*len(s.m) {
+		if err := s.compactWAL(); err != nil {
+			w.err = fmt.Errorf("goset: compacting log: %s", err)
+			return w.err
+		}
+	}
+	return nil
+}
+
+// walErr returns the first failure of the log of s, if any, without writing
+// the records logged so far. The write lock must be held.
+func (s *Set) walErr() error {
+	if s.wal == nil {
+		return nil
+	}
+	return s.wal.err
+}
+
+// compactWAL replaces the snapshot of s with its current items, then starts a
+// new, empty log. The write lock must be held and the log flushed.
+func (s *Set) compactWAL() error {
+	w := s.wal
+
+	items := make([]interface{}, 0, len(s.m))
+	for item := range s.m {
+		items = append(items, item)
+	}
+	err := writeFileAtomic(w.path+walSnapshotSuffix, func(f io.Writer) error {
+		return s.save(f, items)
+	})
+	if err != nil {
+		return err
+	}
+
+	err = writeFileAtomic(w.path, func(f io.Writer) error {
+		return writeWALHeader(f, s.kind)
+	})
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(w.path, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return err
+	}
+
+	w.f.Close()
+	w.f, w.records = f, 0
+	return nil
+}
+
+func writeWALHeader(w io.Writer, kind reflect.Kind) error {
+	header := make([]byte, walHeaderSize)
+	copy(header, walMagic)
+	binary.BigEndian.PutUint32(header[len(walMagic):], walVersion)
+	binary.BigEndian.PutUint32(header[len(walMagic)+4:], uint32(kind))
+	_, err := w.Write(header)
+	return err
+}
+
+func readWALHeader(data []byte) (reflect.Kind, error) {
+	if len(data) < walHeaderSize || string(data[:len(walMagic)]) != walMagic {
+		return 0, errors.New("not a write-ahead log")
+	}
+	if v := binary.BigEndian.Uint32(data[len(walMagic):]); v != walVersion {
+		return 0, fmt.Errorf("unsupported version %d", v)
+	}
+	return reflect.Kind(binary.BigEndian.Uint32(data[len(walMagic)+4:])), nil
+}
+
+// decodeWALRecord decodes the record at the start of data and returns it with
+// its length.
+func decodeWALRecord(data []byte) (walRecord, int, error) {
+	var rec walRecord
+	if len(data) < 8 {
+		return rec, 0, errTornRecord
+	}
+
+	size := int(binary.BigEndian.Uint32(data[:4]))
+	if len(data)-8 < size {
+		return rec, 0, errTornRecord
+	}
+	payload := data[8 : 8+size]
+	if crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(data[4:8]) {
+		if len(data) == 8+size {
+			return rec, 0, errTornRecord
+		}
+		return rec, 0, errors.New("checksum mismatch")
+	}
+
+	if err := gob.NewDecoder(bytes.NewReader(payload)).Decode(&rec); err != nil {
+		return rec, 0, err
+	}
+	return rec, 8 + size, nil
+}
@@ -0,0 +1,203 @@
+package goset
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestWAL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "set.wal")
+
+	s, err := NewWAL(path, reflect.String)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Add("a", "b", "c")
+	s.Remove("b")
+	s.PopRandom()
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewWAL(path, reflect.String); err == nil {
+		t.Error("NewWAL: an existing log shouldn't be overwritten")
+	}
+
+	r, err := Recover(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := r.IsEqual(s); !ok {
+		t.Errorf("Recover: expected %v, got %v", s, r)
+	}
+
+	r.Add("d")
+	r.Close()
+	r, err = Recover(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := r.Has("d"); !ok {
+		t.Error("Recover: a recovered set should carry on logging")
+	}
+	r.Close()
+}
+
+func TestWAL_tornRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "set.wal")
+
+	s, _ := NewWAL(path, reflect.Int)
+	s.Add(1, 2)
+	s.Close()
+
+	info, _ := os.Stat(path)
+	f, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	f.Write([]byte{0, 0, 0, 42, 1, 2})
+	f.Close()
+
+	r, err := Recover(path)
+	if err != nil {
+		t.Fatalf("Recover: a torn record should be discarded, got %s", err)
+	}
+	if ok, _ := r.IsEqual(s); !ok {
+		t.Errorf("Recover: expected %v, got %v", s, r)
+	}
+	r.Close()
+
+	if truncated, _ := os.Stat(path); truncated.Size() != info.Size() {
+		t.Errorf("Recover: the torn record should be truncated, size %d instead of %d", truncated.Size(), info.Size())
+	}
+}
+
+func TestWAL_corruptRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "set.wal")
+
+	s, _ := NewWAL(path, reflect.Int)
+	s.Add(1)
+	s.Add(2)
+	s.Close()
+
+	data, _ := os.ReadFile(path)
+	data[walHeaderSize+8] ^= 0xff
+	os.WriteFile(path, data, 0o644)
+
+	if _, err := Recover(path); err == nil {
+		t.Error("Recover: a damaged record that isn't the last one should return an error")
+	}
+}
+
+func TestWAL_compaction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "set.wal")
+
+	s, _ := NewWAL(path, reflect.Int)
+	items := make([]interface{}, walCompactMin)
+	for i := range items {
+		items[i] = i
+	}
+	s.Add(items...)
+	s.Remove(items[10:]...)
+
+	if _, err := os.Stat(path + walSnapshotSuffix); err != nil {
+		t.Errorf("compaction: the log should be compacted into a snapshot: %s", err)
+	}
+	if info, _ := os.Stat(path); info.Size() != int64(walHeaderSize) {
+		t.Errorf("compaction: the log should be truncated, size is %d", info.Size())
+	}
+
+	s.Add(-1)
+	s.Close()
+
+	r, err := Recover(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := r.IsEqual(s); !ok || r.Size() != 11 {
+		t.Errorf("Recover: expected %v, got %v", s, r)
+	}
+	r.Close()
+
+	if err := New(reflect.Int).CompactWAL(); err == nil {
+		t.Error("CompactWAL: sets without a log should return an error")
+	}
+}
+
+func TestWAL_writeErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "set.wal")
+
+	s, _ := NewWAL(path, reflect.Int)
+	s.wal.f.Close() // make every write fail
+
+	if err := s.Merge(New(reflect.Int, 1)); err == nil {
+		t.Error("Merge: a failure to write the log should be returned")
+	}
+	if _, err := s.GetOrAdd(2); err == nil {
+		t.Error("GetOrAdd: a failure of the log should be returned")
+	}
+	if _, err := s.CompareAndSwap(1, 3); err == nil {
+		t.Error("CompareAndSwap: a failure of the log should be returned")
+	}
+	if err := s.RetainAll(New(reflect.Int)); err == nil {
+		t.Error("RetainAll: a failure of the log should be returned")
+	}
+}
+
+func TestWAL_Err(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "set.wal")
+
+	s, _ := NewWAL(path, reflect.Int)
+	s.Add(1, 2, 3)
+	if err := s.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	s.wal.f.Close() // make every write fail
+	s.RemoveIf(func(item interface{}) bool { return item.(int) > 1 })
+	if err := s.Err(); err == nil {
+		t.Error("Err: a failure to write the log of RemoveIf should be returned")
+	}
+	s.Clear()
+	if err := s.Err(); err == nil {
+		t.Error("Err: failures of the log should be sticky")
+	}
+
+	if err := New(reflect.Int).Err(); err != nil {
+		t.Errorf("Err: sets without a log should return nil, got %s", err)
+	}
+}
+
+func TestWAL_Do(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "set.wal")
+
+	s, _ := NewWAL(path, reflect.String)
+	s.Add("a")
+	info, _ := os.Stat(path)
+
+	err := s.Do(func(tx SetTx) {
+		tx.Remove("a")
+		tx.Add("b")
+		if written, _ := os.Stat(path); written.Size() != info.Size() {
+			t.Error("Do: the log should only be written once fn returns")
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+
+	r, err := Recover(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := r.IsEqual(New(reflect.String, "b")); !ok {
+		t.Errorf("Recover: expected [b], got %s", r)
+	}
+	r.Close()
+
+	s, _ = NewWAL(filepath.Join(t.TempDir(), "set.wal"), reflect.String)
+	s.wal.f.Close() // make every write fail
+	if err := s.Do(func(tx SetTx) { tx.Add("a") }); err == nil {
+		t.Error("Do: a failure to write the log should be returned")
+	}
+}
@@ -0,0 +1,115 @@
+package goset
+
+import (
+	"context"
+	"sync"
+)
+
+// SlowConsumerPolicy decides what a watch does with an event when the buffer
+// of its channel is full.
+type SlowConsumerPolicy int
+
+const (
+	// WatchBlock waits for the consumer to receive the event. The operation
+	// that caused the event doesn't return before that, although it doesn't
+	// hold the lock of the set anymore.
+	WatchBlock SlowConsumerPolicy = iota
+
+	// WatchDrop drops the event.
+	WatchDrop
+
+	// WatchClose stops the watch and closes the channel. The consumer can
+	// tell it apart from a cancelled context with the error of its context.
+	WatchClose
+)
+
+// WatchOption configures a watch created with Set.Watch.
+type WatchOption func(*watchOptions)
+
+type watchOptions struct {
+	buffer int
+	policy SlowConsumerPolicy
+}
+
+// WithBuffer sets the buffer size of the watch channel. It's zero by default.
+func WithBuffer(n int) WatchOption {
+	return func(o *watchOptions) {
+		o.buffer = n
+	}
+}
+
+// WithSlowConsumerPolicy sets what happens to events when the buffer of the
+// watch channel is full. It's WatchBlock by default.
+func WithSlowConsumerPolicy(policy SlowConsumerPolicy) WatchOption {
+	return func(o *watchOptions) {
+		o.policy = policy
+	}
+}
+
+// Watch returns a channel that receives a ChangeEvent for every change of s.
+// Events are sent after the operation released the lock of s, so events of
+// operations running concurrently may arrive in any order; only the events of
+// operations made one after the other, such as by a single goroutine, arrive
+// in order. The channel is closed once ctx is done, or when the consumer is
+// too slow with the WatchClose policy. Events must not be modified, they're
+// shared by all observers.
+func (s *Set) Watch(ctx context.Context, opts ...WatchOption) <-chan ChangeEvent {
+	o := &watchOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	ch := make(chan ChangeEvent, o.buffer)
+	stop := make(chan struct{})
+
+	var (
+		mu     sync.Mutex
+		closed bool
+	)
+	cancel := s.observe(&observer{fn: func(ev ChangeEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if closed {
+			return
+		}
+
+		switch o.policy {
+		case WatchBlock:
+			select {
+			case ch <- ev:
+			case <-ctx.Done():
+			}
+		case WatchDrop:
+			select {
+			case ch <- ev:
+			default:
+			}
+		case WatchClose:
+			select {
+			case ch <- ev:
+			default:
+				closed = true
+				close(ch)
+				close(stop)
+			}
+		}
+	}})
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-stop:
+		}
+		cancel()
+
+		mu.Lock()
+		defer mu.Unlock()
+		if !closed {
+			closed = true
+			close(ch)
+		}
+	}()
+
+	return ch
+}
@@ -0,0 +1,103 @@
+package goset_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/fatih/goset"
+	"github.com/fatih/goset/settest"
+)
+
+func TestSet_Watch(t *testing.T) {
+	s := goset.New(reflect.Int, 1)
+	settest.VerifyNoLeaks(t, s)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := s.Watch(ctx, goset.WithBuffer(10))
+
+	s.Add(2, 3)
+	s.Remove(1)
+	s.Clear()
+
+	expected := []goset.ChangeEvent{
+		{Op: goset.OpAdd, Items: []interface{}{2, 3}},
+		{Op: goset.OpRemove, Items: []interface{}{1}},
+	}
+	for _, e := range expected {
+		if ev := <-ch; !reflect.DeepEqual(ev, e) {
+			t.Errorf("Watch: expected %v, got %v", e, ev)
+		}
+	}
+
+	ev := <-ch
+	if ev.Op != goset.OpClear || len(ev.Items) != 2 {
+		t.Errorf("Watch: expected clear of two items, got %v", ev)
+	}
+
+	cancel()
+	if _, ok := <-ch; ok {
+		t.Error("Watch: the channel should be closed once the context is done")
+	}
+}
+
+func TestSet_Watch_drop(t *testing.T) {
+	s := goset.New(reflect.Int)
+	settest.VerifyNoLeaks(t, s)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := s.Watch(ctx, goset.WithBuffer(1), goset.WithSlowConsumerPolicy(goset.WatchDrop))
+
+	s.Add(1)
+	s.Add(2)
+
+	if ev := <-ch; ev.Items[0] != 1 {
+		t.Errorf("Watch: expected the first event, got %v", ev)
+	}
+
+	select {
+	case ev := <-ch:
+		t.Errorf("Watch: the second event should have been dropped, got %v", ev)
+	default:
+	}
+}
+
+func TestSet_Watch_close(t *testing.T) {
+	s := goset.New(reflect.Int)
+	settest.VerifyNoLeaks(t, s)
+
+	ch := s.Watch(context.Background(), goset.WithSlowConsumerPolicy(goset.WatchClose))
+
+	s.Add(1) // nobody is receiving
+
+	if _, ok := <-ch; ok {
+		t.Error("Watch: the channel should be closed for a slow consumer")
+	}
+}
+
+func TestSet_Watch_block(t *testing.T) {
+	s := goset.New(reflect.Int)
+	settest.VerifyNoLeaks(t, s)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ch := s.Watch(ctx)
+
+	done := make(chan struct{})
+	go func() {
+		s.Add(1) // blocks until the event is received
+		s.Add(2) // blocks until the context is cancelled
+		close(done)
+	}()
+
+	if ev := <-ch; ev.Items[0] != 1 {
+		t.Errorf("Watch: expected the first event, got %v", ev)
+	}
+
+	cancel()
+	<-done
+}
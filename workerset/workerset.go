@@ -0,0 +1,163 @@
+// Package workerset manages the membership of a pool of workers on top of
+// goset: registration, heartbeats, draining and sticky assignment of keys to
+// healthy workers.
+package workerset
+
+import (
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/fatih/goset"
+	"github.com/fatih/goset/internal/hashmix"
+)
+
+// Set tracks the workers of a pool. A worker is healthy if it's registered,
+// not draining, and sent a heartbeat within the TTL of the set. All methods
+// are safe for concurrent use.
+type Set struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	workers    *goset.Set // all registered workers
+	draining   *goset.Set // registered workers that take no new work
+	heartbeats map[string]time.Time
+
+	now func() time.Time // replaced in tests
+}
+
+// New creates an empty worker set. Workers that haven't sent a heartbeat
+// within ttl are unhealthy.
+func New(ttl time.Duration) *Set {
+	return &Set{
+		ttl:        ttl,
+		workers:    goset.New(reflect.String),
+		draining:   goset.New(reflect.String),
+		heartbeats: make(map[string]time.Time),
+		now:        time.Now,
+	}
+}
+
+// Register adds a worker to the set. Registering counts as a heartbeat. A
+// worker that registers again stops draining.
+func (s *Set) Register(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.workers.Add(id)
+	s.draining.Remove(id)
+	s.heartbeats[id] = s.now()
+}
+
+// Deregister removes a worker from the set.
+func (s *Set) Deregister(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.workers.Remove(id)
+	s.draining.Remove(id)
+	delete(s.heartbeats, id)
+}
+
+// Heartbeat records that a worker is alive. It returns an error if the worker
+// isn't registered.
+func (s *Set) Heartbeat(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if ok, _ := s.workers.Has(id); !ok {
+		return fmt.Errorf("workerset: worker %q is not registered", id)
+	}
+	s.heartbeats[id] = s.now()
+	return nil
+}
+
+// Drain marks a worker as draining: it stays registered, but is no longer
+// healthy and gets no new assignments. It returns an error if the worker isn't
+// registered.
+func (s *Set) Drain(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if ok, _ := s.workers.Has(id); !ok {
+		return fmt.Errorf("workerset: worker %q is not registered", id)
+	}
+	s.draining.Add(id)
+	return nil
+}
+
+// Workers returns a copy of the set of registered workers.
+func (s *Set) Workers() *goset.Set {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.workers.Copy()
+}
+
+// Draining returns a copy of the set of draining workers.
+func (s *Set) Draining() *goset.Set {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.draining.Copy()
+}
+
+// Healthy returns the set of healthy workers.
+func (s *Set) Healthy() *goset.Set {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.healthy()
+}
+
+func (s *Set) healthy() *goset.Set {
+	active, _ := s.workers.Difference(s.draining)
+	deadline := s.now().Add(-s.ttl)
+	alive, _ := active.Partition(func(item interface{}) bool {
+		return !s.heartbeats[item.(string)].Before(deadline)
+	})
+	return alive
+}
+
+// Expire deregisters the workers that haven't sent a heartbeat within the TTL
+// and returns them.
+func (s *Set) Expire() *goset.Set {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deadline := s.now().Add(-s.ttl)
+	expired, _ := s.workers.Partition(func(item interface{}) bool {
+		return s.heartbeats[item.(string)].Before(deadline)
+	})
+
+	s.workers.Separate(expired)
+	s.draining.Separate(expired)
+	for _, id := range expired.StringSlice() {
+		delete(s.heartbeats, id)
+	}
+	return expired
+}
+
+// Assign returns the healthy worker responsible for key. Assignments are
+// sticky: a key stays on its worker as long as that worker is healthy, and
+// only the keys of a worker that becomes unhealthy move elsewhere (rendezvous
+// hashing). It returns false if there is no healthy worker.
+func (s *Set) Assign(key string) (string, bool) {
+	s.mu.Lock()
+	healthy := s.healthy()
+	s.mu.Unlock()
+
+	var (
+		best  string
+		score uint64
+		found bool
+	)
+	for _, id := range healthy.StringSlice() {
+		h := fnv.New64a()
+		h.Write([]byte(id))
+		h.Write([]byte{0})
+		h.Write([]byte(key))
+		if sum := hashmix.Mix(h.Sum64()); !found || sum > score || (sum == score && id < best) {
+			best, score, found = id, sum, true
+		}
+	}
+	return best, found
+}
@@ -0,0 +1,106 @@
+package workerset
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func newTestSet() (*Set, *time.Time) {
+	clock := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := New(time.Minute)
+	s.now = func() time.Time { return clock }
+	return s, &clock
+}
+
+func TestSet_Healthy(t *testing.T) {
+	s, clock := newTestSet()
+	s.Register("a")
+	s.Register("b")
+	s.Register("c")
+
+	if err := s.Drain("b"); err != nil {
+		t.Fatal("Drain: unexpected error", err)
+	}
+
+	*clock = clock.Add(2 * time.Minute)
+	s.Heartbeat("a")
+
+	healthy := s.Healthy()
+	if ok, _ := healthy.Has("a"); !ok || healthy.Size() != 1 {
+		t.Errorf("Healthy: only a should be healthy, got %s", healthy)
+	}
+
+	if err := s.Heartbeat("d"); err == nil {
+		t.Error("Heartbeat: an unknown worker should return an error")
+	}
+	if err := s.Drain("d"); err == nil {
+		t.Error("Drain: an unknown worker should return an error")
+	}
+
+	s.Register("b")
+	if s.Draining().Size() != 0 || s.Healthy().Size() != 2 {
+		t.Error("Register: registering again should stop draining")
+	}
+}
+
+func TestSet_Expire(t *testing.T) {
+	s, clock := newTestSet()
+	s.Register("a")
+	s.Register("b")
+	s.Drain("b")
+
+	*clock = clock.Add(2 * time.Minute)
+	s.Heartbeat("a")
+
+	expired := s.Expire()
+	if ok, _ := expired.Has("b"); !ok || expired.Size() != 1 {
+		t.Errorf("Expire: only b should expire, got %s", expired)
+	}
+
+	if s.Workers().Size() != 1 || s.Draining().Size() != 0 {
+		t.Error("Expire: expired workers should be deregistered")
+	}
+}
+
+func TestSet_Assign(t *testing.T) {
+	s, _ := newTestSet()
+
+	if _, ok := s.Assign("key"); ok {
+		t.Error("Assign: there should be no assignment without workers")
+	}
+
+	for _, id := range []string{"a", "b", "c", "d"} {
+		s.Register(id)
+	}
+
+	before := make(map[string]string)
+	for i := 0; i < 1000; i++ {
+		key := fmt.Sprintf("key%d", i)
+		before[key], _ = s.Assign(key)
+	}
+
+	s.Drain("c")
+	moved := 0
+	for key, worker := range before {
+		after, _ := s.Assign(key)
+		if after == "c" {
+			t.Fatal("Assign: a draining worker should get no assignment")
+		}
+		if worker != "c" && after != worker {
+			t.Fatalf("Assign: key %s moved from healthy worker %s to %s", key, worker, after)
+		}
+		if worker != after {
+			moved++
+		}
+	}
+
+	if moved == 0 || moved > 400 {
+		t.Errorf("Assign: expected about a quarter of the keys to move, %d did", moved)
+	}
+
+	s.Deregister("a")
+	if ok, _ := s.Workers().Has("a"); ok {
+		t.Error("Deregister: worker should be removed")
+	}
+}
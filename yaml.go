@@ -0,0 +1,24 @@
+package goset
+
+import "fmt"
+
+// MarshalYAML implements the Marshaler interface of the gopkg.in/yaml
+// packages. The set is encoded as a sequence of its items in sorted order.
+func (s *Set) MarshalYAML() (interface{}, error) {
+	return s.sortedList(), nil
+}
+
+// UnmarshalYAML implements the Unmarshaler interface of gopkg.in/yaml.v2,
+// which gopkg.in/yaml.v3 supports as well. It decodes a sequence of scalars
+// and replaces the items of s with them, converted to the kind of s. If s
+// has no kind, as a zero Set, the kind is inferred from the values.
+func (s *Set) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var values []interface{}
+	if err := unmarshal(&values); err != nil {
+		return fmt.Errorf("goset: decoding YAML: %s", err)
+	}
+	if err := s.replaceWith(values); err != nil {
+		return fmt.Errorf("goset: decoding YAML: %s", err)
+	}
+	return nil
+}
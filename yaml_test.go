@@ -0,0 +1,52 @@
+package goset
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// yamlValues returns an unmarshal function as passed by the yaml packages,
+// decoding the given values.
+func yamlValues(values ...interface{}) func(interface{}) error {
+	return func(v interface{}) error {
+		*v.(*[]interface{}) = values
+		return nil
+	}
+}
+
+func TestSet_MarshalYAML(t *testing.T) {
+	v, err := New(reflect.Int, 3, 1, 2).MarshalYAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(v, []interface{}{1, 2, 3}) {
+		t.Errorf("MarshalYAML: expected a sorted sequence, got %v", v)
+	}
+}
+
+func TestSet_UnmarshalYAML(t *testing.T) {
+	s := New(reflect.Int64, 9)
+	if err := s.UnmarshalYAML(yamlValues(1, "2", int64(3))); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := s.IsEqual(New(reflect.Int64, int64(1), int64(2), int64(3))); !ok {
+		t.Errorf("UnmarshalYAML: expected [1 2 3], got %s", s)
+	}
+
+	var z Set
+	if err := z.UnmarshalYAML(yamlValues("a", "b")); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := z.Has("a", "b"); z.Kind() != reflect.String || !ok {
+		t.Errorf("UnmarshalYAML: expected a string set [a b], got %s", &z)
+	}
+
+	if err := New(reflect.Int).UnmarshalYAML(yamlValues("x")); err == nil {
+		t.Error("UnmarshalYAML: values that can't be converted should return an error")
+	}
+	failing := func(interface{}) error { return errors.New("not a sequence") }
+	if err := New(reflect.Int).UnmarshalYAML(failing); err == nil {
+		t.Error("UnmarshalYAML: decoding errors should be returned")
+	}
+}